@@ -0,0 +1,168 @@
+package catalog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/myscribae/myscribae-sdk-go/gql"
+	"github.com/myscribae/myscribae-sdk-go/provider"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+// FieldDiff is a single changed field, with both values formatted for display
+type FieldDiff struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// DiffScriptGroup compares two script group states field by field.  A nil
+// state is treated as missing, so every field of the other state is reported.
+func DiffScriptGroup(current, desired *gql.ScriptGroupProfile) []FieldDiff {
+	var currentFields, desiredFields []field
+	if current != nil {
+		currentFields = scriptGroupFields(*current)
+	}
+	if desired != nil {
+		desiredFields = scriptGroupFields(*desired)
+	}
+
+	return diffFields(currentFields, desiredFields)
+}
+
+// DiffScript compares two script states field by field.  A nil state is
+// treated as missing, so every field of the other state is reported.
+func DiffScript(current, desired *gql.ScriptProfile) []FieldDiff {
+	var currentFields, desiredFields []field
+	if current != nil {
+		currentFields = scriptFields(*current)
+	}
+	if desired != nil {
+		desiredFields = scriptFields(*desired)
+	}
+
+	return diffFields(currentFields, desiredFields)
+}
+
+type field struct {
+	name  string
+	value string
+}
+
+func scriptGroupFields(profile gql.ScriptGroupProfile) []field {
+	return []field{
+		{"name", profile.Name},
+		{"description", profile.Description},
+		{"public", strconv.FormatBool(profile.Public)},
+		{"archived", strconv.FormatBool(profile.Archived)},
+		{"metadata", profile.Metadata.String()},
+	}
+}
+
+func scriptFields(profile gql.ScriptProfile) []field {
+	return []field{
+		{"name", profile.Name},
+		{"description", profile.Description},
+		{"recurrence", describeRecurrence(profile.Recurrence)},
+		{"price", utilities.NewCentValue(profile.PriceInCents).String()},
+		{"sla", FormatDuration(profile.Sla)},
+		{"token_lifetime", FormatDuration(profile.TokenLifetime)},
+		{"public", strconv.FormatBool(profile.Public)},
+		{"archived", strconv.FormatBool(profile.Archived)},
+		{"metadata", profile.Metadata.String()},
+	}
+}
+
+func diffFields(current, desired []field) []FieldDiff {
+	count := len(current)
+	if len(desired) > count {
+		count = len(desired)
+	}
+
+	var diffs []FieldDiff
+	for i := 0; i < count; i++ {
+		var diff FieldDiff
+		if i < len(current) {
+			diff.Field = current[i].name
+			diff.Old = current[i].value
+		}
+		if i < len(desired) {
+			diff.Field = desired[i].name
+			diff.New = desired[i].value
+		}
+
+		if i < len(current) && i < len(desired) && diff.Old == diff.New {
+			continue
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
+
+// FormatDuration formats a duration as days, hours, minutes and whole
+// seconds, for example (1h 30m) or (45s)
+func FormatDuration(d time.Duration) string {
+	seconds := uint(utilities.DurationSeconds(d).Seconds())
+	if seconds == 0 {
+		return "0s"
+	}
+
+	units := []struct {
+		suffix  string
+		seconds uint
+	}{
+		{"d", 24 * 60 * 60},
+		{"h", 60 * 60},
+		{"m", 60},
+		{"s", 1},
+	}
+
+	var parts []string
+	for _, unit := range units {
+		if seconds >= unit.seconds {
+			parts = append(parts, fmt.Sprintf("%d%s", seconds/unit.seconds, unit.suffix))
+			seconds %= unit.seconds
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// describeRecurrence makes a recurrence readable, keeping unparsable values as is
+func describeRecurrence(recurrence string) string {
+	parsed, err := utilities.NewRecurrence(recurrence)
+	if err != nil {
+		return recurrence
+	}
+
+	return parsed.Describe()
+}
+
+func scriptGroupProfile(altID string, input provider.CreateScriptGroupInput) gql.ScriptGroupProfile {
+	return gql.ScriptGroupProfile{
+		AltID:       altID,
+		Name:        input.Name,
+		Description: input.Description,
+		Public:      input.Public,
+		Metadata:    input.Metadata,
+	}
+}
+
+func scriptProfile(groupAltID string, input provider.CreateScriptInput) gql.ScriptProfile {
+	return gql.ScriptProfile{
+		AltID:            input.AltID,
+		ScriptGroupAltID: groupAltID,
+		Name:             input.Name,
+		Description:      input.Description,
+		Recurrence:       input.Recurrence.String(),
+		PriceInCents:     input.PriceInCents.CentsValue(),
+		Sla:              utilities.RoundToSeconds(input.Sla),
+		TokenLifetime:    utilities.RoundToSeconds(input.TokenLifetime),
+		Public:           input.Public,
+		Metadata:         input.Metadata,
+	}
+}
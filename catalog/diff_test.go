@@ -0,0 +1,82 @@
+package catalog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/myscribae/myscribae-sdk-go/catalog"
+	"github.com/myscribae/myscribae-sdk-go/gql"
+)
+
+func TestDiffScript(t *testing.T) {
+	old := gql.ScriptProfile{
+		Name:          "Summarize",
+		Recurrence:    "monthly",
+		PriceInCents:  499,
+		Sla:           90 * time.Second,
+		TokenLifetime: time.Hour,
+		Public:        true,
+	}
+	new := old
+	new.PriceInCents = 999
+	new.Sla = 90 * time.Minute
+
+	diffs := catalog.DiffScript(&old, &new)
+	expected := []catalog.FieldDiff{
+		{Field: "price", Old: "4.99", New: "9.99"},
+		{Field: "sla", Old: "1m 30s", New: "1h 30m"},
+	}
+
+	if len(diffs) != len(expected) {
+		t.Fatalf("expected %d diffs, got %d: %v", len(expected), len(diffs), diffs)
+	}
+
+	for i := range expected {
+		if diffs[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], diffs[i])
+		}
+	}
+
+	if diffs := catalog.DiffScript(&old, &old); len(diffs) != 0 {
+		t.Errorf("expected no diffs for identical scripts, got %v", diffs)
+	}
+}
+
+func TestRenderEmptyPlan(t *testing.T) {
+	plan := &catalog.Plan{}
+
+	var text bytes.Buffer
+	if err := plan.RenderText(&text, true); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text.String(), catalog.NoChangesMessage) {
+		t.Errorf("expected the no changes message, got %q", text.String())
+	}
+
+	var markdown bytes.Buffer
+	if err := plan.RenderMarkdown(&markdown); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(markdown.String(), catalog.NoChangesMessage) {
+		t.Errorf("expected the no changes message, got %q", markdown.String())
+	}
+
+	var out bytes.Buffer
+	if err := plan.RenderJSON(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded struct {
+		Empty   bool          `json:"empty"`
+		Changes []interface{} `json:"changes"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Empty || decoded.Changes == nil || len(decoded.Changes) != 0 {
+		t.Errorf("expected an empty json plan, got %s", out.String())
+	}
+}
@@ -0,0 +1,110 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/myscribae/myscribae-sdk-go/gql"
+	"github.com/myscribae/myscribae-sdk-go/provider"
+)
+
+// ExportVersion is the version of the export document written by this sdk
+const ExportVersion = 1
+
+// Export is a snapshot of a provider's full catalog
+type Export struct {
+	Version      int                 `json:"version"`
+	ExportedAt   time.Time           `json:"exported_at"`
+	Provider     gql.ProviderProfile `json:"provider"`
+	ScriptGroups []ExportScriptGroup `json:"script_groups"`
+}
+
+// ExportScriptGroup is a script group and its scripts within an export
+type ExportScriptGroup struct {
+	gql.ScriptGroupProfile
+	Scripts []gql.ScriptProfile `json:"scripts"`
+}
+
+// ExportCatalog snapshots the provider profile, script groups and scripts
+func ExportCatalog(ctx context.Context, prov *provider.Provider) (*Export, error) {
+	profile, err := prov.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := LoadRemoteState(ctx, prov)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &Export{
+		Version:      ExportVersion,
+		ExportedAt:   time.Now().UTC(),
+		Provider:     *profile,
+		ScriptGroups: make([]ExportScriptGroup, 0, len(remote.ScriptGroups)),
+	}
+
+	for _, group := range remote.ScriptGroups {
+		exported := ExportScriptGroup{
+			ScriptGroupProfile: remote.groups[group.AltID],
+			Scripts:            make([]gql.ScriptProfile, 0, len(group.Scripts)),
+		}
+
+		for _, script := range group.Scripts {
+			exported.Scripts = append(exported.Scripts, remote.scripts[group.AltID][script.AltID])
+		}
+
+		export.ScriptGroups = append(export.ScriptGroups, exported)
+	}
+
+	return export, nil
+}
+
+// Write encodes the export as indented json
+func (e *Export) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(e)
+}
+
+// ReadExport decodes an export document, rejecting versions this sdk does not understand
+func ReadExport(r io.Reader) (*Export, error) {
+	var export Export
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("failed to parse export: %w", err)
+	}
+
+	if export.Version < 1 || export.Version > ExportVersion {
+		return nil, fmt.Errorf("unsupported export version %d. expecting version %d or lower", export.Version, ExportVersion)
+	}
+
+	return &export, nil
+}
+
+// Manifest converts the export into a manifest of its script groups and
+// scripts.  Manifests have no archived flag, Import archives the items that
+// were archived when exported.
+func (e *Export) Manifest() *Manifest {
+	manifest := &Manifest{
+		ScriptGroups: make([]ScriptGroupManifest, 0, len(e.ScriptGroups)),
+	}
+
+	for _, group := range e.ScriptGroups {
+		desired := ScriptGroupManifest{
+			AltID:                  group.AltID,
+			CreateScriptGroupInput: scriptGroupInput(group.ScriptGroupProfile),
+			Scripts:                make([]provider.CreateScriptInput, 0, len(group.Scripts)),
+		}
+
+		for _, script := range group.Scripts {
+			desired.Scripts = append(desired.Scripts, scriptInput(script))
+		}
+
+		manifest.ScriptGroups = append(manifest.ScriptGroups, desired)
+	}
+
+	return manifest
+}
@@ -0,0 +1,91 @@
+package catalog_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/myscribae/myscribae-sdk-go/catalog"
+	"github.com/myscribae/myscribae-sdk-go/gql"
+)
+
+func TestExportRoundTrip(t *testing.T) {
+	export := &catalog.Export{
+		Version:  catalog.ExportVersion,
+		Provider: gql.ProviderProfile{Uuid: uuid.New(), Name: "Production"},
+		ScriptGroups: []catalog.ExportScriptGroup{
+			{
+				ScriptGroupProfile: gql.ScriptGroupProfile{Uuid: uuid.New(), AltID: "tools", Name: "Tools", Public: false},
+				Scripts: []gql.ScriptProfile{
+					{Uuid: uuid.New(), AltID: "summarize", ScriptGroupAltID: "tools", Name: "Summarize", Recurrence: "monthly", PriceInCents: 500, Sla: time.Minute, TokenLifetime: time.Hour, Public: true},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := export.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := catalog.ReadExport(&buf)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+
+	manifest := read.Manifest()
+	if err := manifest.Validate(); err != nil {
+		t.Fatalf("exported manifest is invalid: %v", err)
+	}
+
+	group := manifest.ScriptGroups[0]
+	if group.AltID != "tools" || group.Public {
+		t.Errorf("script group was not preserved: %+v", group)
+	}
+
+	script := group.Scripts[0]
+	if script.AltID != "summarize" || !script.Public || script.PriceInCents.CentsValue() != 500 {
+		t.Errorf("script was not preserved: %+v", script)
+	}
+}
+
+func TestReadExportUnsupportedVersion(t *testing.T) {
+	if _, err := catalog.ReadExport(strings.NewReader(`{"version": 99}`)); err == nil {
+		t.Fatalf("expected an unsupported version error")
+	}
+}
+
+func TestPlanImport(t *testing.T) {
+	export := &catalog.Export{
+		Version: catalog.ExportVersion,
+		ScriptGroups: []catalog.ExportScriptGroup{
+			{
+				ScriptGroupProfile: gql.ScriptGroupProfile{Uuid: uuid.New(), AltID: "legacy", Name: "Legacy", Archived: true},
+				Scripts: []gql.ScriptProfile{
+					{Uuid: uuid.New(), AltID: "summarize", ScriptGroupAltID: "legacy", Name: "Summarize", Recurrence: "monthly", PriceInCents: 500, Sla: time.Minute, TokenLifetime: time.Hour, Archived: true},
+					{Uuid: uuid.New(), AltID: "translate", ScriptGroupAltID: "legacy", Name: "Translate", Recurrence: "every full moon", PriceInCents: 500, Sla: time.Minute, TokenLifetime: time.Hour},
+				},
+			},
+		},
+	}
+
+	report, err := catalog.PlanImport(export, catalog.NewRemoteState(nil, nil), catalog.ImportOptions{})
+	if err != nil {
+		t.Fatalf("expected the unparsable recurrence not to fail the import, got %v", err)
+	}
+
+	if len(report.Conflicts) != 1 || report.Conflicts[0].Path != "legacy/translate" {
+		t.Errorf("expected the unparsable recurrence to be a conflict, got %v", report.Conflicts)
+	}
+
+	var steps []string
+	for _, change := range report.Plan.Changes {
+		steps = append(steps, string(change.Action)+" "+change.Path())
+	}
+	expected := "create legacy, create legacy/summarize, archive legacy/summarize, archive legacy"
+	if strings.Join(steps, ", ") != expected {
+		t.Errorf("expected %s, got %s", expected, strings.Join(steps, ", "))
+	}
+}
@@ -0,0 +1,188 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/myscribae/myscribae-sdk-go/provider"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+// ImportOptions controls how an export is recreated on a provider
+type ImportOptions struct {
+	// AltIDs maps the path of an exported script group (group) or script
+	// (group/script) to the alt id to use on the target provider
+	AltIDs map[string]string
+	// Profile also copies the exported provider profile onto the target provider
+	Profile bool
+	// DryRun reports what the import would do without changing the target provider
+	DryRun bool
+}
+
+// Conflict is an exported item that could not be imported
+type Conflict struct {
+	Resource Resource `json:"resource"`
+	// Path is the path of the item on the target provider
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s %s: %s", c.Resource, c.Path, c.Reason)
+}
+
+// ImportReport is the outcome of an import
+type ImportReport struct {
+	// Plan holds the creates the import performed, or would perform on a dry run
+	Plan           *Plan
+	Conflicts      []Conflict
+	ProfileUpdated bool
+}
+
+// Import recreates an exported catalog on a provider.  Script groups and
+// scripts that already exist on the target are left untouched and reported as
+// conflicts, and the public and archived flags of every imported item are
+// preserved.
+func Import(ctx context.Context, prov *provider.Provider, export *Export, opts ImportOptions) (*ImportReport, error) {
+	remote, err := LoadRemoteState(ctx, prov)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := PlanImport(export, remote, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.DryRun {
+		report.ProfileUpdated = opts.Profile
+		return report, nil
+	}
+
+	if opts.Profile {
+		if _, err := prov.Update(ctx, profileUpdate(export)); err != nil {
+			return report, fmt.Errorf("failed to import provider profile: %w", err)
+		}
+		report.ProfileUpdated = true
+	}
+
+	if err := report.Plan.Apply(ctx, prov); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// PlanImport compares an export against the remote state of the target
+// provider, without changing it.  Archived items are created and then
+// archived, and scripts whose recurrence cannot be parsed are reported as
+// conflicts instead of failing the whole import.
+func PlanImport(export *Export, remote *RemoteState, opts ImportOptions) (*ImportReport, error) {
+	report := &ImportReport{Plan: &Plan{}}
+
+	// archived holds the target paths of the items that were archived when exported
+	archived := make(map[string]bool)
+	manifest := export.Manifest()
+	for i, group := range manifest.ScriptGroups {
+		exported := export.ScriptGroups[i]
+
+		groupAltID := group.AltID
+		if altID, ok := opts.AltIDs[group.AltID]; ok {
+			groupAltID = altID
+		}
+		archived[groupAltID] = exported.Archived
+
+		scripts := make([]provider.CreateScriptInput, 0, len(group.Scripts))
+		for j, script := range group.Scripts {
+			if altID, ok := opts.AltIDs[group.AltID+"/"+script.AltID]; ok {
+				script.AltID = altID
+			}
+			path := groupAltID + "/" + script.AltID
+
+			recurrence := exported.Scripts[j].Recurrence
+			if _, err := utilities.NewRecurrence(recurrence); err != nil {
+				report.Conflicts = append(report.Conflicts, Conflict{
+					Resource: ResourceScript,
+					Path:     path,
+					Reason:   fmt.Sprintf("recurrence %q cannot be parsed: %s", recurrence, err),
+				})
+				continue
+			}
+
+			archived[path] = exported.Scripts[j].Archived
+			scripts = append(scripts, script)
+		}
+
+		manifest.ScriptGroups[i].AltID = groupAltID
+		manifest.ScriptGroups[i].Scripts = scripts
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+
+	// archiving waits until every item is created, scripts before their groups
+	var scriptChanges, scriptArchiveChanges, groupArchiveChanges []Change
+	for _, group := range manifest.ScriptGroups {
+		if _, exists := remote.groups[group.AltID]; exists {
+			report.Conflicts = append(report.Conflicts, Conflict{
+				Resource: ResourceScriptGroup,
+				Path:     group.AltID,
+				Reason:   "script group already exists, its missing scripts are still imported",
+			})
+		} else {
+			create := createScriptGroupChange(group.AltID, group.CreateScriptGroupInput)
+			report.Plan.Changes = append(report.Plan.Changes, create)
+			if archived[group.AltID] {
+				groupArchiveChanges = append(groupArchiveChanges, archivedChange(ActionArchive, ResourceScriptGroup, group.AltID, "", create.DesiredVersion))
+			}
+		}
+
+		for _, script := range group.Scripts {
+			path := group.AltID + "/" + script.AltID
+			if _, exists := remote.scripts[group.AltID][script.AltID]; exists {
+				report.Conflicts = append(report.Conflicts, Conflict{
+					Resource: ResourceScript,
+					Path:     path,
+					Reason:   "script already exists",
+				})
+				continue
+			}
+
+			create := createScriptChange(group.AltID, script)
+			scriptChanges = append(scriptChanges, create)
+			if archived[path] {
+				scriptArchiveChanges = append(scriptArchiveChanges, archivedChange(ActionArchive, ResourceScript, group.AltID, script.AltID, create.DesiredVersion))
+			}
+		}
+	}
+	report.Plan.Changes = append(report.Plan.Changes, scriptChanges...)
+	report.Plan.Changes = append(report.Plan.Changes, scriptArchiveChanges...)
+	report.Plan.Changes = append(report.Plan.Changes, groupArchiveChanges...)
+
+	return report, nil
+}
+
+// profileUpdate copies the exported profile, except for the alt id which
+// belongs to the source provider.  Fields missing from the export are cleared.
+func profileUpdate(export *Export) provider.UpdateProviderProfileInput {
+	profile := export.Provider
+	return provider.UpdateProviderProfileInput{
+		Name:           utilities.NewOptional(profile.Name),
+		CategoryID:     utilities.NewOptional(profile.Category),
+		Description:    utilities.NewOptional(profile.Description),
+		LogoUrl:        nullableString(profile.LogoUrl),
+		BannerUrl:      nullableString(profile.BannerUrl),
+		Url:            nullableString(profile.Url),
+		Color:          nullableString(profile.Color),
+		Public:         utilities.NewOptional(profile.Public),
+		AccountService: utilities.NewOptional(profile.AccountService.Enabled),
+	}
+}
+
+func nullableString(s *string) utilities.Optional[string] {
+	if s == nil {
+		return utilities.NullOptional[string]()
+	}
+	return utilities.NewOptional(*s)
+}
@@ -0,0 +1,134 @@
+package catalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/myscribae/myscribae-sdk-go/provider"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the encoding of a manifest file
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+var (
+	ErrUnknownManifestFormat = errors.New("unknown manifest format. expecting one of (.json, .yaml, .yml)")
+	ErrDuplicateAltID        = errors.New("duplicate alt id in manifest")
+)
+
+// Manifest is the desired state of a provider's catalog
+type Manifest struct {
+	ScriptGroups []ScriptGroupManifest `json:"script_groups"`
+}
+
+// ScriptGroupManifest is the desired state of a script group and its scripts
+type ScriptGroupManifest struct {
+	AltID string `json:"alt_id"`
+	provider.CreateScriptGroupInput
+	Scripts []provider.CreateScriptInput `json:"scripts"`
+}
+
+// LoadManifest reads a manifest file, picking the format from its extension
+func LoadManifest(path string) (*Manifest, error) {
+	var format Format
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		format = FormatJSON
+	case ".yaml", ".yml":
+		format = FormatYAML
+	default:
+		return nil, ErrUnknownManifestFormat
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseManifest(data, format)
+}
+
+// ParseManifest decodes and validates a manifest
+func ParseManifest(data []byte, format Format) (*Manifest, error) {
+	switch format {
+	case FormatJSON:
+	case FormatYAML:
+		// yaml is converted to json so the sdk input types only need json tags
+		var raw interface{}
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse yaml manifest: %w", err)
+		}
+
+		converted, err := json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert yaml manifest: %w", err)
+		}
+		data = converted
+	default:
+		return nil, ErrUnknownManifestFormat
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
+
+// Validate checks the alt ids of the manifest are unique, and that every
+// script group and script is valid.  Invalid inputs return a
+// *provider.ValidationError with fields nested under their path in the manifest.
+func (m *Manifest) Validate() error {
+	groups := make(map[string]bool)
+	for i, group := range m.ScriptGroups {
+		if _, err := utilities.NewAltUuid(group.AltID); err != nil {
+			return err
+		}
+
+		if err := group.CreateScriptGroupInput.Validate(); err != nil {
+			return prefixValidation(err, fmt.Sprintf("script_groups[%d]", i))
+		}
+
+		if groups[group.AltID] {
+			return fmt.Errorf("%w: script group %s", ErrDuplicateAltID, group.AltID)
+		}
+		groups[group.AltID] = true
+
+		scripts := make(map[string]bool)
+		for j, script := range group.Scripts {
+			if err := script.Validate(); err != nil {
+				return prefixValidation(err, fmt.Sprintf("script_groups[%d].scripts[%d]", i, j))
+			}
+
+			if scripts[script.AltID] {
+				return fmt.Errorf("%w: script %s/%s", ErrDuplicateAltID, group.AltID, script.AltID)
+			}
+			scripts[script.AltID] = true
+		}
+	}
+
+	return nil
+}
+
+func prefixValidation(err error, path string) error {
+	var validationErr *provider.ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Prefix(path)
+	}
+	return err
+}
@@ -0,0 +1,319 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/myscribae/myscribae-sdk-go/provider"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+// Action is what a change does to a remote resource
+type Action string
+
+const (
+	ActionCreate  Action = "create"
+	ActionUpdate  Action = "update"
+	ActionArchive Action = "archive"
+	ActionRestore Action = "restore"
+)
+
+// Resource is the kind of remote resource a change applies to
+type Resource string
+
+const (
+	ResourceScriptGroup Resource = "script_group"
+	ResourceScript      Resource = "script"
+)
+
+// Change is a single step of a plan
+type Change struct {
+	Action           Action
+	Resource         Resource
+	ScriptGroupAltID string
+	// AltID is the script's alt id, and is empty for script group changes
+	AltID string
+
+	CurrentVersion string
+	DesiredVersion string
+
+	// Diffs lists the fields the change sets
+	Diffs []FieldDiff
+
+	// desired state of the script group, set for script group creates and updates
+	ScriptGroup       *provider.CreateScriptGroupInput
+	ScriptGroupUpdate *provider.UpdateScriptGroupInput
+
+	// desired state of the script, set for script creates and updates
+	Script       *provider.CreateScriptInput
+	ScriptUpdate *provider.UpdateScriptInput
+}
+
+// Path identifies the changed resource as (group) or (group/script)
+func (c Change) Path() string {
+	if c.Resource == ResourceScriptGroup {
+		return c.ScriptGroupAltID
+	}
+
+	return c.ScriptGroupAltID + "/" + c.AltID
+}
+
+// Plan is the ordered list of changes that brings the remote state in line with a manifest
+type Plan struct {
+	Changes []Change
+}
+
+// Empty reports whether the remote state already matches the manifest
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// NewPlan compares a manifest against the remote state.  Remote script groups
+// and scripts that are missing from the manifest are archived, and archived
+// ones that are in the manifest are restored.
+func NewPlan(manifest *Manifest, remote *RemoteState) (*Plan, error) {
+	// changes are collected per phase so groups exist before their scripts are
+	// created, and scripts are archived before their groups
+	var (
+		groupChanges         []Change
+		scriptChanges        []Change
+		scriptArchiveChanges []Change
+		groupArchiveChanges  []Change
+	)
+
+	desiredGroups := make(map[string]bool)
+	for _, group := range manifest.ScriptGroups {
+		desiredGroups[group.AltID] = true
+
+		desiredGroup := group.CreateScriptGroupInput
+		desiredVersion := ScriptGroupVersion(desiredGroup)
+		desiredProfile := scriptGroupProfile(group.AltID, desiredGroup)
+
+		current, exists := remote.groups[group.AltID]
+		currentGroup := scriptGroupInput(current)
+		if desiredGroup.Metadata == nil {
+			// the manifest does not manage the metadata, so keep it as it is
+			currentGroup.Metadata = nil
+			desiredProfile.Metadata = current.Metadata
+		}
+		if exists && current.Archived {
+			groupChanges = append(groupChanges, archivedChange(ActionRestore, ResourceScriptGroup, group.AltID, "", ScriptGroupVersion(currentGroup)))
+		}
+
+		if !exists {
+			groupChanges = append(groupChanges, createScriptGroupChange(group.AltID, desiredGroup))
+		} else if currentVersion := ScriptGroupVersion(currentGroup); currentVersion != desiredVersion {
+			update := provider.ScriptGroupChanges(current, desiredGroup)
+			// applying fails if the script group changed after planning, unless
+			// the restore before it changes the version
+			if !current.Archived {
+				update.IfMatch = &current.Version
+			}
+			// restoring is a separate change
+			current.Archived = false
+			groupChanges = append(groupChanges, Change{
+				Action:            ActionUpdate,
+				Resource:          ResourceScriptGroup,
+				ScriptGroupAltID:  group.AltID,
+				CurrentVersion:    currentVersion,
+				DesiredVersion:    desiredVersion,
+				Diffs:             DiffScriptGroup(&current, &desiredProfile),
+				ScriptGroup:       &desiredGroup,
+				ScriptGroupUpdate: &update,
+			})
+		}
+
+		desiredScripts := make(map[string]bool)
+		for _, script := range group.Scripts {
+			desiredScripts[script.AltID] = true
+
+			desiredScript := script
+			desiredVersion := ScriptVersion(desiredScript)
+			desiredProfile := scriptProfile(group.AltID, desiredScript)
+
+			current, exists := remote.scripts[group.AltID][script.AltID]
+			if !exists {
+				scriptChanges = append(scriptChanges, createScriptChange(group.AltID, desiredScript))
+				continue
+			}
+
+			currentScript := scriptInput(current)
+			if desiredScript.Metadata == nil {
+				currentScript.Metadata = nil
+				desiredProfile.Metadata = current.Metadata
+			}
+			currentVersion := ScriptVersion(currentScript)
+			if current.Archived {
+				scriptChanges = append(scriptChanges, archivedChange(ActionRestore, ResourceScript, group.AltID, script.AltID, currentVersion))
+			}
+
+			if currentVersion == desiredVersion {
+				continue
+			}
+
+			update, err := provider.ScriptChanges(current, desiredScript)
+			if err != nil {
+				return nil, fmt.Errorf("script %s/%s: %w", group.AltID, script.AltID, err)
+			}
+			// applying fails if the script changed after planning, unless the
+			// restore before it changes the version
+			if !current.Archived {
+				update.IfMatch = &current.Version
+			}
+			current.Archived = false
+
+			scriptChanges = append(scriptChanges, Change{
+				Action:           ActionUpdate,
+				Resource:         ResourceScript,
+				ScriptGroupAltID: group.AltID,
+				AltID:            script.AltID,
+				CurrentVersion:   currentVersion,
+				DesiredVersion:   desiredVersion,
+				Diffs:            DiffScript(&current, &desiredProfile),
+				Script:           &desiredScript,
+				ScriptUpdate:     &update,
+			})
+		}
+
+		scriptArchiveChanges = append(scriptArchiveChanges, archiveScripts(remote, group.AltID, desiredScripts)...)
+	}
+
+	for _, group := range remote.ScriptGroups {
+		if desiredGroups[group.AltID] {
+			continue
+		}
+
+		scriptArchiveChanges = append(scriptArchiveChanges, archiveScripts(remote, group.AltID, nil)...)
+
+		if !remote.groups[group.AltID].Archived {
+			groupArchiveChanges = append(groupArchiveChanges, archivedChange(ActionArchive, ResourceScriptGroup, group.AltID, "", group.Version))
+		}
+	}
+
+	changes := make([]Change, 0, len(groupChanges)+len(scriptChanges)+len(scriptArchiveChanges)+len(groupArchiveChanges))
+	changes = append(changes, groupChanges...)
+	changes = append(changes, scriptChanges...)
+	changes = append(changes, scriptArchiveChanges...)
+	changes = append(changes, groupArchiveChanges...)
+
+	return &Plan{Changes: changes}, nil
+}
+
+func createScriptGroupChange(altID string, desired provider.CreateScriptGroupInput) Change {
+	profile := scriptGroupProfile(altID, desired)
+	return Change{
+		Action:           ActionCreate,
+		Resource:         ResourceScriptGroup,
+		ScriptGroupAltID: altID,
+		DesiredVersion:   ScriptGroupVersion(desired),
+		Diffs:            DiffScriptGroup(nil, &profile),
+		ScriptGroup:      &desired,
+	}
+}
+
+func createScriptChange(groupAltID string, desired provider.CreateScriptInput) Change {
+	profile := scriptProfile(groupAltID, desired)
+	return Change{
+		Action:           ActionCreate,
+		Resource:         ResourceScript,
+		ScriptGroupAltID: groupAltID,
+		AltID:            desired.AltID,
+		DesiredVersion:   ScriptVersion(desired),
+		Diffs:            DiffScript(nil, &profile),
+		Script:           &desired,
+	}
+}
+
+// archivedChange toggles the archived flag of a script group or script
+func archivedChange(action Action, resource Resource, groupAltID, altID, version string) Change {
+	diff := FieldDiff{Field: "archived", Old: "false", New: "true"}
+	if action == ActionRestore {
+		diff.Old, diff.New = diff.New, diff.Old
+	}
+
+	return Change{
+		Action:           action,
+		Resource:         resource,
+		ScriptGroupAltID: groupAltID,
+		AltID:            altID,
+		CurrentVersion:   version,
+		DesiredVersion:   version,
+		Diffs:            []FieldDiff{diff},
+	}
+}
+
+// archiveScripts archives the scripts of a remote group that are not desired
+func archiveScripts(remote *RemoteState, groupAltID string, desired map[string]bool) []Change {
+	var changes []Change
+	for _, group := range remote.ScriptGroups {
+		if group.AltID != groupAltID {
+			continue
+		}
+
+		for _, script := range group.Scripts {
+			if desired[script.AltID] || remote.scripts[groupAltID][script.AltID].Archived {
+				continue
+			}
+
+			changes = append(changes, archivedChange(ActionArchive, ResourceScript, groupAltID, script.AltID, script.Version))
+		}
+	}
+
+	return changes
+}
+
+// Apply runs every change of the plan in order, stopping at the first failure
+func (p *Plan) Apply(ctx context.Context, prov *provider.Provider) error {
+	for _, change := range p.Changes {
+		if err := change.apply(ctx, prov); err != nil {
+			return fmt.Errorf("failed to %s %s %s: %w", change.Action, change.Resource, change.Path(), err)
+		}
+	}
+
+	return nil
+}
+
+func (c Change) apply(ctx context.Context, prov *provider.Provider) error {
+	if c.Resource == ResourceScriptGroup {
+		sg, err := prov.ScriptGroup(c.ScriptGroupAltID)
+		if err != nil {
+			return err
+		}
+
+		switch c.Action {
+		case ActionCreate:
+			_, err = sg.Create(ctx, *c.ScriptGroup)
+		case ActionUpdate:
+			_, err = sg.Update(ctx, *c.ScriptGroupUpdate)
+		case ActionArchive:
+			err = sg.Archive(ctx)
+		case ActionRestore:
+			err = sg.Restore(ctx)
+		}
+
+		return err
+	}
+
+	groupID, err := utilities.NewAltUuid(c.ScriptGroupAltID)
+	if err != nil {
+		return err
+	}
+
+	script, err := prov.Script(groupID, c.AltID)
+	if err != nil {
+		return err
+	}
+
+	switch c.Action {
+	case ActionCreate:
+		_, err = script.Create(ctx, *c.Script)
+	case ActionUpdate:
+		_, err = script.Update(ctx, *c.ScriptUpdate)
+	case ActionArchive:
+		err = script.Archive(ctx)
+	case ActionRestore:
+		err = script.Restore(ctx)
+	}
+
+	return err
+}
@@ -0,0 +1,154 @@
+package catalog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/myscribae/myscribae-sdk-go/catalog"
+	"github.com/myscribae/myscribae-sdk-go/gql"
+)
+
+const testManifest = `
+script_groups:
+  - alt_id: tools
+    name: Tools
+    description: Useful tools
+    public: true
+    scripts:
+      - alt_id: summarize
+        name: Summarize
+        description: Summarizes text
+        recurrence: monthly
+        price_in_cents: 500
+        sla_sec: 60
+        token_lifetime_sec: 3600
+        public: true
+      - alt_id: translate
+        name: Translate
+        description: Translates text
+        recurrence: monthly
+        price_in_cents: 900
+        sla_sec: 60
+        token_lifetime_sec: 3600
+        public: true
+`
+
+func TestNewPlan(t *testing.T) {
+	manifest, err := catalog.ParseManifest([]byte(testManifest), catalog.FormatYAML)
+	if err != nil {
+		t.Fatalf("failed to parse manifest: %v", err)
+	}
+
+	remote := catalog.NewRemoteState(
+		[]gql.ScriptGroupProfile{
+			{Uuid: uuid.New(), AltID: "tools", Name: "Tools", Description: "Useful tools", Public: true},
+			{Uuid: uuid.New(), AltID: "legacy", Name: "Legacy", Description: "Old tools", Public: true},
+		},
+		[]gql.ScriptProfile{
+			{
+				Uuid:             uuid.New(),
+				AltID:            "summarize",
+				ScriptGroupAltID: "tools",
+				Name:             "Summarize",
+				Description:      "Summarizes text",
+				Recurrence:       "monthly",
+				PriceInCents:     400,
+				Sla:              time.Minute,
+				TokenLifetime:    time.Hour,
+				Public:           true,
+			},
+			{
+				Uuid:             uuid.New(),
+				AltID:            "old",
+				ScriptGroupAltID: "legacy",
+				Name:             "Old",
+				Recurrence:       "monthly",
+				Public:           true,
+			},
+		},
+	)
+
+	plan, err := catalog.NewPlan(manifest, remote)
+	if err != nil {
+		t.Fatalf("failed to plan: %v", err)
+	}
+
+	expected := []struct {
+		action catalog.Action
+		path   string
+	}{
+		{catalog.ActionUpdate, "tools/summarize"},
+		{catalog.ActionCreate, "tools/translate"},
+		{catalog.ActionArchive, "legacy/old"},
+		{catalog.ActionArchive, "legacy"},
+	}
+
+	if len(plan.Changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %d", len(expected), len(plan.Changes))
+	}
+
+	for i, change := range plan.Changes {
+		if change.Action != expected[i].action || change.Path() != expected[i].path {
+			t.Errorf("change %d: expected %s %s, got %s %s", i, expected[i].action, expected[i].path, change.Action, change.Path())
+		}
+	}
+
+	update := plan.Changes[0].ScriptUpdate
+	if price, ok := update.PriceInCents.Get(); !ok || price.CentsValue() != 500 {
+		t.Errorf("expected price update to 500 cents")
+	}
+
+	if update.Name.IsSet() || update.Description.IsSet() {
+		t.Errorf("expected only changed fields in the update")
+	}
+}
+
+func TestNewPlanNoChanges(t *testing.T) {
+	manifest, err := catalog.ParseManifest([]byte(`{"script_groups": [{"alt_id": "tools", "name": "Tools", "public": true}]}`), catalog.FormatJSON)
+	if err != nil {
+		t.Fatalf("failed to parse manifest: %v", err)
+	}
+
+	remote := catalog.NewRemoteState(
+		[]gql.ScriptGroupProfile{{Uuid: uuid.New(), AltID: "tools", Name: "Tools", Public: true}},
+		nil,
+	)
+
+	plan, err := catalog.NewPlan(manifest, remote)
+	if err != nil {
+		t.Fatalf("failed to plan: %v", err)
+	}
+
+	if !plan.Empty() {
+		t.Errorf("expected an empty plan, got %d changes", len(plan.Changes))
+	}
+}
+
+func TestParseManifestDuplicateAltID(t *testing.T) {
+	_, err := catalog.ParseManifest([]byte(`{"script_groups": [{"alt_id": "tools"}, {"alt_id": "tools"}]}`), catalog.FormatJSON)
+	if err == nil {
+		t.Fatalf("expected duplicate alt id error")
+	}
+}
+
+func TestNewPlanRestoresArchived(t *testing.T) {
+	manifest, err := catalog.ParseManifest([]byte(`{"script_groups": [{"alt_id": "tools", "name": "Tools", "public": true}]}`), catalog.FormatJSON)
+	if err != nil {
+		t.Fatalf("failed to parse manifest: %v", err)
+	}
+
+	remote := catalog.NewRemoteState(
+		[]gql.ScriptGroupProfile{{Uuid: uuid.New(), AltID: "tools", Name: "Tools", Public: true, Archived: true}},
+		nil,
+	)
+
+	plan, err := catalog.NewPlan(manifest, remote)
+	if err != nil {
+		t.Fatalf("failed to plan: %v", err)
+	}
+
+	if len(plan.Changes) != 1 || plan.Changes[0].Action != catalog.ActionRestore {
+		t.Fatalf("expected a single restore, got %+v", plan.Changes)
+	}
+}
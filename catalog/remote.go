@@ -0,0 +1,145 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/myscribae/myscribae-sdk-go/gql"
+	"github.com/myscribae/myscribae-sdk-go/provider"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+// RemoteState is the current state of a provider's catalog
+type RemoteState struct {
+	ScriptGroups []gql.RemoteScriptGroup
+
+	groups  map[string]gql.ScriptGroupProfile
+	scripts map[string]map[string]gql.ScriptProfile
+}
+
+// LoadRemoteState reads every script group and script of the provider
+func LoadRemoteState(ctx context.Context, prov *provider.Provider) (*RemoteState, error) {
+	groups, err := prov.ListScriptGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var scripts []gql.ScriptProfile
+	it := prov.IterateAllScripts(provider.ListScriptsOptions{})
+	for it.Next(ctx) {
+		scripts = append(scripts, *it.Script())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewRemoteState(groups, scripts), nil
+}
+
+// NewRemoteState indexes script group and script profiles by alt id
+func NewRemoteState(groups []gql.ScriptGroupProfile, scripts []gql.ScriptProfile) *RemoteState {
+	state := &RemoteState{
+		ScriptGroups: make([]gql.RemoteScriptGroup, 0, len(groups)),
+		groups:       make(map[string]gql.ScriptGroupProfile),
+		scripts:      make(map[string]map[string]gql.ScriptProfile),
+	}
+
+	for _, group := range groups {
+		state.groups[group.AltID] = group
+		state.scripts[group.AltID] = make(map[string]gql.ScriptProfile)
+	}
+
+	for _, script := range scripts {
+		if _, ok := state.scripts[script.ScriptGroupAltID]; !ok {
+			continue
+		}
+		state.scripts[script.ScriptGroupAltID][script.AltID] = script
+	}
+
+	for _, group := range groups {
+		remote := gql.RemoteScriptGroup{
+			Uuid:    group.Uuid,
+			AltID:   group.AltID,
+			Version: ScriptGroupVersion(scriptGroupInput(group)),
+			Scripts: make([]gql.RemoteScript, 0, len(state.scripts[group.AltID])),
+		}
+
+		for _, script := range scripts {
+			if script.ScriptGroupAltID != group.AltID {
+				continue
+			}
+
+			remote.Scripts = append(remote.Scripts, gql.RemoteScript{
+				Uuid:    script.Uuid,
+				AltID:   script.AltID,
+				Version: ScriptVersion(scriptInput(script)),
+			})
+		}
+
+		state.ScriptGroups = append(state.ScriptGroups, remote)
+	}
+
+	return state
+}
+
+// ScriptGroupVersion hashes the fields of a script group that the manifest manages
+func ScriptGroupVersion(input provider.CreateScriptGroupInput) string {
+	return utilities.VersionHash([]sql.NullString{
+		utilities.NotNullString(input.Name),
+		utilities.NotNullString(input.Description),
+		utilities.NotNullString(strconv.FormatBool(input.Public)),
+		metadataVersion(input.Metadata),
+	})
+}
+
+// ScriptVersion hashes the fields of a script that the manifest manages
+func ScriptVersion(input provider.CreateScriptInput) string {
+	return utilities.VersionHash([]sql.NullString{
+		utilities.NotNullString(input.Name),
+		utilities.NotNullString(input.Description),
+		utilities.NotNullString(input.Recurrence.String()),
+		utilities.NotNullString(input.PriceInCents.String()),
+		utilities.NotNullString(utilities.DurationSeconds(input.Sla).String()),
+		utilities.NotNullString(utilities.DurationSeconds(input.TokenLifetime).String()),
+		utilities.NotNullString(strconv.FormatBool(input.Public)),
+		metadataVersion(input.Metadata),
+	})
+}
+
+// metadataVersion leaves unmanaged (nil) metadata out of the version
+func metadataVersion(metadata utilities.Metadata) sql.NullString {
+	if metadata == nil {
+		return sql.NullString{}
+	}
+	return utilities.NotNullString("metadata:" + metadata.String())
+}
+
+func scriptGroupInput(profile gql.ScriptGroupProfile) provider.CreateScriptGroupInput {
+	return provider.CreateScriptGroupInput{
+		Name:        profile.Name,
+		Description: profile.Description,
+		Public:      profile.Public,
+		Metadata:    profile.Metadata,
+	}
+}
+
+func scriptInput(profile gql.ScriptProfile) provider.CreateScriptInput {
+	// an unparsable remote recurrence is left unset, and shows up as a change
+	var recurrence utilities.Recurrence
+	if parsed, err := utilities.NewRecurrence(profile.Recurrence); err == nil {
+		recurrence = *parsed
+	}
+
+	return provider.CreateScriptInput{
+		AltID:         profile.AltID,
+		Name:          profile.Name,
+		Description:   profile.Description,
+		Recurrence:    recurrence,
+		PriceInCents:  utilities.NewCentValue(profile.PriceInCents),
+		Sla:           profile.Sla,
+		TokenLifetime: profile.TokenLifetime,
+		Public:        profile.Public,
+		Metadata:      profile.Metadata,
+	}
+}
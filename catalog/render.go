@@ -0,0 +1,204 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+)
+
+// NoChangesMessage is rendered for a plan without changes
+const NoChangesMessage = "No changes. The catalog is up to date."
+
+// Summary counts the changes of a plan by action
+type Summary struct {
+	Create  int `json:"create"`
+	Update  int `json:"update"`
+	Archive int `json:"archive"`
+	Restore int `json:"restore"`
+}
+
+// Summary counts the changes of the plan by action
+func (p *Plan) Summary() Summary {
+	var summary Summary
+	for _, change := range p.Changes {
+		switch change.Action {
+		case ActionCreate:
+			summary.Create++
+		case ActionUpdate:
+			summary.Update++
+		case ActionArchive:
+			summary.Archive++
+		case ActionRestore:
+			summary.Restore++
+		}
+	}
+
+	return summary
+}
+
+func (s Summary) String() string {
+	return fmt.Sprintf("%d to create, %d to update, %d to archive, %d to restore", s.Create, s.Update, s.Archive, s.Restore)
+}
+
+func (a Action) symbol() string {
+	switch a {
+	case ActionCreate:
+		return "+"
+	case ActionUpdate:
+		return "~"
+	case ActionArchive:
+		return "-"
+	case ActionRestore:
+		return "^"
+	}
+
+	return "?"
+}
+
+func (a Action) color() string {
+	switch a {
+	case ActionCreate:
+		return colorGreen
+	case ActionUpdate:
+		return colorYellow
+	case ActionArchive:
+		return colorRed
+	case ActionRestore:
+		return colorGreen
+	}
+
+	return ""
+}
+
+// RenderText writes the plan for a terminal, using ansi colors when color is set
+func (p *Plan) RenderText(w io.Writer, color bool) error {
+	if p.Empty() {
+		_, err := fmt.Fprintln(w, NoChangesMessage)
+		return err
+	}
+
+	paint := func(c, s string) string {
+		if !color || c == "" {
+			return s
+		}
+		return c + s + colorReset
+	}
+
+	var b strings.Builder
+	for _, change := range p.Changes {
+		fmt.Fprintf(&b, "%s %s %s\n", paint(change.Action.color(), change.Action.symbol()), change.Resource, change.Path())
+
+		for _, diff := range change.Diffs {
+			switch change.Action {
+			case ActionCreate:
+				fmt.Fprintf(&b, "    %s: %s\n", diff.Field, paint(colorGreen, quote(diff.New)))
+			default:
+				fmt.Fprintf(&b, "    %s: %s -> %s\n", diff.Field, paint(colorRed, quote(diff.Old)), paint(colorGreen, quote(diff.New)))
+			}
+		}
+	}
+	fmt.Fprintf(&b, "\nPlan: %s.\n", p.Summary())
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type jsonChange struct {
+	Action           Action      `json:"action"`
+	Resource         Resource    `json:"resource"`
+	Path             string      `json:"path"`
+	ScriptGroupAltID string      `json:"script_group_alt_id"`
+	AltID            string      `json:"alt_id,omitempty"`
+	CurrentVersion   string      `json:"current_version,omitempty"`
+	DesiredVersion   string      `json:"desired_version,omitempty"`
+	Fields           []FieldDiff `json:"fields"`
+}
+
+type jsonPlan struct {
+	Empty   bool         `json:"empty"`
+	Summary Summary      `json:"summary"`
+	Changes []jsonChange `json:"changes"`
+}
+
+// RenderJSON writes the plan as a json document for automated tooling
+func (p *Plan) RenderJSON(w io.Writer) error {
+	out := jsonPlan{
+		Empty:   p.Empty(),
+		Summary: p.Summary(),
+		Changes: make([]jsonChange, 0, len(p.Changes)),
+	}
+
+	for _, change := range p.Changes {
+		fields := change.Diffs
+		if fields == nil {
+			fields = []FieldDiff{}
+		}
+
+		out.Changes = append(out.Changes, jsonChange{
+			Action:           change.Action,
+			Resource:         change.Resource,
+			Path:             change.Path(),
+			ScriptGroupAltID: change.ScriptGroupAltID,
+			AltID:            change.AltID,
+			CurrentVersion:   change.CurrentVersion,
+			DesiredVersion:   change.DesiredVersion,
+			Fields:           fields,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// RenderMarkdown writes the plan as a markdown summary, suitable for pull request comments
+func (p *Plan) RenderMarkdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("### Catalog plan\n\n")
+
+	if p.Empty() {
+		b.WriteString(NoChangesMessage + "\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	fmt.Fprintf(&b, "**Plan:** %s.\n", p.Summary())
+
+	for _, change := range p.Changes {
+		fmt.Fprintf(&b, "\n#### `%s` %s `%s`\n", change.Action.symbol(), change.Resource, change.Path())
+
+		if len(change.Diffs) == 0 {
+			continue
+		}
+
+		b.WriteString("\n| Field | Current | Desired |\n| --- | --- | --- |\n")
+		for _, diff := range change.Diffs {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", diff.Field, markdownCell(diff.Old), markdownCell(diff.New))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func quote(s string) string {
+	return fmt.Sprintf("%q", s)
+}
+
+func markdownCell(s string) string {
+	if s == "" {
+		return "_none_"
+	}
+
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return s
+}
@@ -1,7 +1,154 @@
 package environment
 
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
 const (
 	ApiUrlEnvVar    = "MYSCRIBAE_API_URL"
 	ApiKeyEnvVar    = "MYSCRIBAE_API_KEY"
 	SecretKeyEnvVar = "MYSCRIBAE_SECRET_KEY"
+	ApiTokenEnvVar  = "MYSCRIBAE_API_TOKEN"
+	// EnvironmentEnvVar names the environment, one of (production), (sandbox) or (custom)
+	EnvironmentEnvVar = "MYSCRIBAE_ENVIRONMENT"
+	// ConfigFileEnvVar is the path of the config file to load
+	ConfigFileEnvVar = "MYSCRIBAE_CONFIG"
+	// ProfileEnvVar selects a profile of the config file
+	ProfileEnvVar = "MYSCRIBAE_PROFILE"
 )
+
+// Name identifies an environment
+type Name string
+
+const (
+	Production Name = "production"
+	Sandbox    Name = "sandbox"
+	// Custom is any other api url, like a local server.  Keys are not checked
+	// against custom environments.
+	Custom Name = "custom"
+)
+
+var (
+	ErrUnknownEnvironment  = errors.New("unknown environment")
+	ErrEnvironmentMismatch = errors.New("key does not belong to the environment")
+	ErrMissingApiUrl       = errors.New("missing myscribae api url")
+)
+
+// Environment is a MyScribae deployment the sdk talks to
+type Environment struct {
+	Name   Name
+	ApiUrl string
+	// KeyPrefix starts every api and secret key issued by the environment,
+	// empty for custom environments
+	KeyPrefix string
+}
+
+var (
+	ProductionEnvironment = Environment{
+		Name:      Production,
+		ApiUrl:    "https://api.myscribae.com/graphql",
+		KeyPrefix: "live_",
+	}
+	SandboxEnvironment = Environment{
+		Name:      Sandbox,
+		ApiUrl:    "https://sandbox.api.myscribae.com/graphql",
+		KeyPrefix: "test_",
+	}
+)
+
+// NewCustomEnvironment returns an environment for any other api url
+func NewCustomEnvironment(apiUrl string) Environment {
+	return Environment{Name: Custom, ApiUrl: apiUrl}
+}
+
+// Named returns the production or sandbox environment
+func Named(name Name) (Environment, error) {
+	switch name {
+	case Production:
+		return ProductionEnvironment, nil
+	case Sandbox:
+		return SandboxEnvironment, nil
+	default:
+		return Environment{}, fmt.Errorf("%w: %s.  expecting (production) or (sandbox)", ErrUnknownEnvironment, name)
+	}
+}
+
+// Resolve picks the environment from a name and an api url, either of which
+// can be empty.  A named environment keeps its key checks when its url is
+// overridden.  An api url alone is the production or sandbox environment when
+// it is one of their urls, and otherwise a custom environment without key
+// checks.  When neither is given it returns ErrMissingApiUrl; production must
+// be named explicitly.
+func Resolve(name Name, apiUrl string) (Environment, error) {
+	if name == "" {
+		for _, env := range []Environment{ProductionEnvironment, SandboxEnvironment} {
+			if apiUrl == env.ApiUrl {
+				return env, nil
+			}
+		}
+	}
+
+	if name == Custom || name == "" {
+		if apiUrl == "" {
+			return Environment{}, ErrMissingApiUrl
+		}
+		return NewCustomEnvironment(apiUrl), nil
+	}
+
+	env, err := Named(name)
+	if err != nil {
+		return Environment{}, err
+	}
+	if apiUrl != "" {
+		env.ApiUrl = apiUrl
+	}
+
+	return env, nil
+}
+
+// FromEnv resolves the environment from MYSCRIBAE_ENVIRONMENT and MYSCRIBAE_API_URL
+func FromEnv() (Environment, error) {
+	return Resolve(Name(os.Getenv(EnvironmentEnvVar)), os.Getenv(ApiUrlEnvVar))
+}
+
+func (e Environment) String() string {
+	if e.Name == Custom {
+		return fmt.Sprintf("%s (%s)", e.Name, e.ApiUrl)
+	}
+	return string(e.Name)
+}
+
+func (e Environment) IsSandbox() bool {
+	return e.Name == Sandbox
+}
+
+// KeyEnvironment returns the environment a key was issued by, from its
+// prefix.  Keys without a known prefix return false.
+func KeyEnvironment(key string) (Name, bool) {
+	for _, env := range []Environment{ProductionEnvironment, SandboxEnvironment} {
+		if strings.HasPrefix(key, env.KeyPrefix) {
+			return env.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// CheckKey returns an error matching ErrEnvironmentMismatch when the key was
+// issued by another environment, like a sandbox key used in production.
+// Keys without a known prefix and custom environments are not checked.
+func (e Environment) CheckKey(key string) error {
+	if e.KeyPrefix == "" {
+		return nil
+	}
+
+	issuer, ok := KeyEnvironment(key)
+	if ok && issuer != e.Name {
+		return fmt.Errorf("%w: a %s key cannot be used in the %s environment", ErrEnvironmentMismatch, issuer, e.Name)
+	}
+
+	return nil
+}
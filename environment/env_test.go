@@ -0,0 +1,65 @@
+package environment_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/myscribae/myscribae-sdk-go/environment"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name     environment.Name
+		apiUrl   string
+		expected environment.Environment
+		err      error
+	}{
+		{"", "", environment.Environment{}, environment.ErrMissingApiUrl},
+		{environment.Production, "", environment.ProductionEnvironment, nil},
+		{environment.Sandbox, "", environment.SandboxEnvironment, nil},
+		{"", "http://localhost:8080/graphql", environment.NewCustomEnvironment("http://localhost:8080/graphql"), nil},
+		{"", environment.ProductionEnvironment.ApiUrl, environment.ProductionEnvironment, nil},
+		{"", environment.SandboxEnvironment.ApiUrl, environment.SandboxEnvironment, nil},
+		{environment.Custom, environment.ProductionEnvironment.ApiUrl, environment.NewCustomEnvironment(environment.ProductionEnvironment.ApiUrl), nil},
+		{
+			environment.Sandbox,
+			"http://localhost:8080/graphql",
+			environment.Environment{Name: environment.Sandbox, ApiUrl: "http://localhost:8080/graphql", KeyPrefix: "test_"},
+			nil,
+		},
+		{environment.Custom, "", environment.Environment{}, environment.ErrMissingApiUrl},
+		{"staging", "", environment.Environment{}, environment.ErrUnknownEnvironment},
+	}
+
+	for _, test := range tests {
+		env, err := environment.Resolve(test.name, test.apiUrl)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s %s: expected error %v, got %v", test.name, test.apiUrl, test.err, err)
+		}
+		if env != test.expected {
+			t.Errorf("%s %s: expected %+v, got %+v", test.name, test.apiUrl, test.expected, env)
+		}
+	}
+}
+
+func TestCheckKey(t *testing.T) {
+	tests := []struct {
+		env      environment.Environment
+		key      string
+		mismatch bool
+	}{
+		{environment.ProductionEnvironment, "live_abc", false},
+		{environment.ProductionEnvironment, "test_abc", true},
+		{environment.SandboxEnvironment, "live_abc", true},
+		{environment.SandboxEnvironment, "test_abc", false},
+		{environment.ProductionEnvironment, "abc", false},
+		{environment.NewCustomEnvironment("http://localhost"), "live_abc", false},
+	}
+
+	for _, test := range tests {
+		err := test.env.CheckKey(test.key)
+		if errors.Is(err, environment.ErrEnvironmentMismatch) != test.mismatch {
+			t.Errorf("%s %s: expected mismatch %t, got %v", test.env, test.key, test.mismatch, err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package general
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/myscribae/myscribae-sdk-go/gql"
+)
+
+var ErrUnknownCategory = errors.New("unknown provider category")
+
+// ProviderCategories lists the categories a provider can be in.  They are
+// fetched once and cached, call RefreshProviderCategories to fetch them again.
+func (m *MyScribae) ProviderCategories(ctx context.Context) ([]gql.ProviderCategory, error) {
+	m.categoriesMu.Lock()
+	defer m.categoriesMu.Unlock()
+
+	if m.categories == nil {
+		if err := m.fetchProviderCategories(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.categories, nil
+}
+
+// RefreshProviderCategories replaces the cached categories with the current ones
+func (m *MyScribae) RefreshProviderCategories(ctx context.Context) ([]gql.ProviderCategory, error) {
+	m.categoriesMu.Lock()
+	defer m.categoriesMu.Unlock()
+
+	if err := m.fetchProviderCategories(ctx); err != nil {
+		return nil, err
+	}
+
+	return m.categories, nil
+}
+
+// ProviderCategory returns the category with the id, or an error matching
+// ErrUnknownCategory when there is none
+func (m *MyScribae) ProviderCategory(ctx context.Context, id string) (*gql.ProviderCategory, error) {
+	categories, err := m.ProviderCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range categories {
+		if categories[i].ID == id {
+			return &categories[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownCategory, id)
+}
+
+func (m *MyScribae) fetchProviderCategories(ctx context.Context) error {
+	var res gql.ListProviderCategories
+	if err := m.Client().Query(ctx, &res, nil); err != nil {
+		return fmt.Errorf("failed to list provider categories: %w", err)
+	}
+
+	categories := res.ProviderCategories
+	if categories == nil {
+		categories = []gql.ProviderCategory{}
+	}
+
+	m.categories = categories
+	return nil
+}
@@ -8,7 +8,7 @@ import (
 	"errors"
 	"log"
 	"net/http"
-	"os"
+	"sync"
 
 	"github.com/hasura/go-graphql-client"
 	"github.com/myscribae/myscribae-sdk-go/environment"
@@ -18,6 +18,9 @@ import (
 type MyScribae struct {
 	publicKey *rsa.PublicKey
 	client    *graphql.Client
+
+	categoriesMu sync.Mutex
+	categories   []gql.ProviderCategory
 }
 
 func NewMyScribae(client *graphql.Client) *MyScribae {
@@ -28,13 +31,13 @@ func NewMyScribae(client *graphql.Client) *MyScribae {
 
 func (m *MyScribae) Client() *graphql.Client {
 	if m.client == nil {
-		var url = os.Getenv(environment.ApiUrlEnvVar)
-		if url == "" {
-			panic("api url not set")
+		env, err := environment.FromEnv()
+		if err != nil {
+			panic(err)
 		}
 
 		m.client = graphql.NewClient(
-			url,
+			env.ApiUrl,
 			&http.Client{},
 		)
 	}
@@ -3,9 +3,11 @@ module github.com/myscribae/myscribae-sdk-go
 go 1.22.6
 
 require (
+	github.com/BurntSushi/toml v1.4.0
 	github.com/golang-jwt/jwt v3.2.2+incompatible
 	github.com/google/uuid v1.6.0
 	github.com/hasura/go-graphql-client v0.12.2
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
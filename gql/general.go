@@ -3,3 +3,14 @@ package gql
 type GetMyScribaePublicKey struct {
 	PublicKey string `graphql:"public_key"`
 }
+
+type ProviderCategory struct {
+	ID   string `graphql:"id" json:"id"`
+	Name string `graphql:"name" json:"name"`
+	// ParentID is the id of the category this one is nested under, if any
+	ParentID *string `graphql:"parent_id" json:"parent_id"`
+}
+
+type ListProviderCategories struct {
+	ProviderCategories []ProviderCategory `graphql:"provider_categories"`
+}
@@ -1,24 +1,33 @@
 package gql
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/google/uuid"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
 )
 
 type ProviderProfile struct {
-	Uuid           uuid.UUID `graphql:"uuid"`
-	AltID          *string   `graphql:"alt_id"`
-	Category       string    `graphql:"category_id"`
-	Name           string    `graphql:"name"`
-	Description    string    `graphql:"description"`
-	Color          *string   `graphql:"color"`
-	LogoUrl        *string   `graphql:"logo_url"`
-	BannerUrl      *string   `graphql:"banner_url"`
-	MyRole         *string   `graphql:"my_role"`
-	Url            *string   `graphql:"url"`
+	Uuid           uuid.UUID `graphql:"uuid" json:"uuid"`
+	AltID          *string   `graphql:"alt_id" json:"alt_id"`
+	Category       string    `graphql:"category_id" json:"category_id"`
+	Name           string    `graphql:"name" json:"name"`
+	Description    string    `graphql:"description" json:"description"`
+	Color          *string   `graphql:"color" json:"color"`
+	LogoUrl        *string   `graphql:"logo_url" json:"logo_url"`
+	BannerUrl      *string   `graphql:"banner_url" json:"banner_url"`
+	MyRole         *string   `graphql:"my_role" json:"my_role"`
+	Url            *string   `graphql:"url" json:"url"`
 	AccountService struct {
-		Enabled bool `graphql:"enabled"`
-	} `graphql:"account_service"`
-	Public bool `graphql:"public"`
+		Enabled bool `graphql:"enabled" json:"enabled"`
+	} `graphql:"account_service" json:"account_service"`
+	Public    bool      `graphql:"public" json:"public"`
+	Version   string    `graphql:"version" json:"version"`
+	CreatedAt time.Time `graphql:"created_at" json:"created_at"`
+	UpdatedAt time.Time `graphql:"updated_at" json:"updated_at"`
+	// UpdatedBy is the user who last changed the provider
+	UpdatedBy string `graphql:"updated_by" json:"updated_by"`
 }
 
 type RemoteScript struct {
@@ -42,10 +51,20 @@ type EditProviderProfile struct {
 	Provider struct {
 		Edit struct {
 			Uuid uuid.UUID
-		} `graphql:"edit(changes:$changes)"`
+		} `graphql:"edit(changes:$changes, if_match:$if_match)"`
 	} `graphql:"provider(id:$id)"`
 }
 
+type UploadImage struct {
+	Provider struct {
+		Images struct {
+			Upload struct {
+				Url string `graphql:"url"`
+			} `graphql:"upload(kind:$kind, file:$file)"`
+		} `graphql:"images"`
+	} `graphql:"provider(id:$provider_id)"`
+}
+
 type GetScriptGroup struct {
 	ProviderSelf struct {
 		ScriptGroup ScriptGroupProfile `graphql:"script_group(id:$id)"`
@@ -53,11 +72,24 @@ type GetScriptGroup struct {
 }
 
 type ScriptGroupProfile struct {
-	Uuid        uuid.UUID `graphql:"uuid"`
-	AltID       string    `graphql:"alt_id"`
-	Name        string    `graphql:"name"`
-	Description string    `graphql:"description"`
-	Public      bool      `graphql:"public"`
+	Uuid        uuid.UUID `graphql:"uuid" json:"uuid"`
+	AltID       string    `graphql:"alt_id" json:"alt_id"`
+	Name        string    `graphql:"name" json:"name"`
+	Description string    `graphql:"description" json:"description"`
+	Public      bool      `graphql:"public" json:"public"`
+	Archived    bool      `graphql:"archived" json:"archived"`
+	Version     string    `graphql:"version" json:"version"`
+	CreatedAt   time.Time `graphql:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `graphql:"updated_at" json:"updated_at"`
+	// UpdatedBy is the user who last changed the script group
+	UpdatedBy string             `graphql:"updated_by" json:"updated_by"`
+	Metadata  utilities.Metadata `graphql:"metadata" scalar:"true" json:"metadata,omitempty"`
+}
+
+type ListScriptGroups struct {
+	ProviderSelf struct {
+		ScriptGroups []ScriptGroupProfile `graphql:"script_groups"`
+	} `graphql:"provider_self(id:$provider_id)"`
 }
 
 type EditScriptGroup struct {
@@ -65,11 +97,49 @@ type EditScriptGroup struct {
 		ScriptGroup struct {
 			Edit struct {
 				Uuid uuid.UUID
-			} `graphql:"edit(changes:$changes)"`
+			} `graphql:"edit(changes:$changes, if_match:$if_match)"`
+		} `graphql:"script_group(id:$id)"`
+	} `graphql:"provider(id:$provider_id)"`
+}
+
+type ArchiveScriptGroup struct {
+	Provider struct {
+		ScriptGroup struct {
+			Archive struct {
+				Uuid uuid.UUID
+			} `graphql:"archive"`
+		} `graphql:"script_group(id:$id)"`
+	} `graphql:"provider(id:$provider_id)"`
+}
+
+type RestoreScriptGroup struct {
+	Provider struct {
+		ScriptGroup struct {
+			Restore struct {
+				Uuid uuid.UUID
+			} `graphql:"restore"`
 		} `graphql:"script_group(id:$id)"`
 	} `graphql:"provider(id:$provider_id)"`
 }
 
+type DeleteScriptGroup struct {
+	Provider struct {
+		ScriptGroup struct {
+			Delete struct {
+				Uuid uuid.UUID
+			} `graphql:"delete"`
+		} `graphql:"script_group(id:$id)"`
+	} `graphql:"provider(id:$provider_id)"`
+}
+
+type GetScriptGroupActiveSubscriptions struct {
+	ProviderSelf struct {
+		ScriptGroup struct {
+			ActiveSubscriptions uint `graphql:"active_subscriptions"`
+		} `graphql:"script_group(id:$id)"`
+	} `graphql:"provider_self(id:$provider_id)"`
+}
+
 type GetScript struct {
 	ProviderSelf struct {
 		ScriptGroup struct {
@@ -79,26 +149,108 @@ type GetScript struct {
 }
 
 type GQLScriptProfile struct {
-	Uuid             uuid.UUID `graphql:"uuid"`
-	AltID            string    `graphql:"alt_id"`
-	Name             string    `graphql:"name"`
-	Description      string    `graphql:"description"`
-	Recurrence       string    `graphql:"recurrence"`
-	PriceInCents     uint      `graphql:"price_in_cents"`
-	SlaSec           int       `graphql:"sla_sec"`
-	TokenLifetimeSec int       `graphql:"token_lifetime_sec"`
-	Public           bool      `graphql:"public"`
+	Uuid             uuid.UUID          `graphql:"uuid"`
+	AltID            string             `graphql:"alt_id"`
+	ScriptGroupUuid  uuid.UUID          `graphql:"script_group_uuid"`
+	ScriptGroupAltID string             `graphql:"script_group_alt_id"`
+	Name             string             `graphql:"name"`
+	Description      string             `graphql:"description"`
+	Recurrence       string             `graphql:"recurrence"`
+	PriceInCents     uint               `graphql:"price_in_cents"`
+	SlaSec           int                `graphql:"sla_sec"`
+	TokenLifetimeSec int                `graphql:"token_lifetime_sec"`
+	Public           bool               `graphql:"public"`
+	Archived         bool               `graphql:"archived"`
+	Version          string             `graphql:"version"`
+	CreatedAt        time.Time          `graphql:"created_at"`
+	UpdatedAt        time.Time          `graphql:"updated_at"`
+	UpdatedBy        string             `graphql:"updated_by"`
+	Metadata         utilities.Metadata `graphql:"metadata" scalar:"true"`
+}
+
+// ScriptProfile converts the raw query result into a ScriptProfile
+func (s GQLScriptProfile) ScriptProfile() ScriptProfile {
+	return ScriptProfile{
+		Uuid:             s.Uuid,
+		AltID:            s.AltID,
+		ScriptGroupUuid:  s.ScriptGroupUuid,
+		ScriptGroupAltID: s.ScriptGroupAltID,
+		Name:             s.Name,
+		Description:      s.Description,
+		Recurrence:       s.Recurrence,
+		PriceInCents:     s.PriceInCents,
+		Sla:              utilities.NewDurationFromSeconds(int64(s.SlaSec)),
+		TokenLifetime:    utilities.NewDurationFromSeconds(int64(s.TokenLifetimeSec)),
+		Public:           s.Public,
+		Archived:         s.Archived,
+		Version:          s.Version,
+		CreatedAt:        s.CreatedAt,
+		UpdatedAt:        s.UpdatedAt,
+		UpdatedBy:        s.UpdatedBy,
+		Metadata:         s.Metadata,
+	}
 }
+
 type ScriptProfile struct {
-	Uuid             uuid.UUID `graphql:"uuid"`
-	AltID            string    `graphql:"alt_id"`
-	Name             string    `graphql:"name"`
-	Description      string    `graphql:"description"`
-	Recurrence       string    `graphql:"recurrence"`
-	PriceInCents     uint      `graphql:"price_in_cents"`
-	SlaSec           uint      `graphql:"sla_sec"`
-	TokenLifetimeSec uint      `graphql:"token_lifetime_sec"`
-	Public           bool      `graphql:"public"`
+	Uuid             uuid.UUID     `graphql:"uuid" json:"uuid"`
+	AltID            string        `graphql:"alt_id" json:"alt_id"`
+	ScriptGroupUuid  uuid.UUID     `graphql:"script_group_uuid" json:"script_group_uuid"`
+	ScriptGroupAltID string        `graphql:"script_group_alt_id" json:"script_group_alt_id"`
+	Name             string        `graphql:"name" json:"name"`
+	Description      string        `graphql:"description" json:"description"`
+	Recurrence       string        `graphql:"recurrence" json:"recurrence"`
+	PriceInCents     uint          `graphql:"price_in_cents" json:"price_in_cents"`
+	Sla              time.Duration `json:"-"`
+	TokenLifetime    time.Duration `json:"-"`
+	Public           bool          `graphql:"public" json:"public"`
+	Archived         bool          `graphql:"archived" json:"archived"`
+	Version          string        `graphql:"version" json:"version"`
+	CreatedAt        time.Time     `graphql:"created_at" json:"created_at"`
+	UpdatedAt        time.Time     `graphql:"updated_at" json:"updated_at"`
+	// UpdatedBy is the user who last changed the script
+	UpdatedBy string             `graphql:"updated_by" json:"updated_by"`
+	Metadata  utilities.Metadata `graphql:"metadata" scalar:"true" json:"metadata,omitempty"`
+}
+
+// MarshalJSON encodes Sla and TokenLifetime as whole seconds, like the api
+func (s ScriptProfile) MarshalJSON() ([]byte, error) {
+	type profile ScriptProfile
+	return json.Marshal(struct {
+		profile
+		SlaSec           utilities.DurationSeconds `json:"sla_sec"`
+		TokenLifetimeSec utilities.DurationSeconds `json:"token_lifetime_sec"`
+	}{profile(s), utilities.DurationSeconds(s.Sla), utilities.DurationSeconds(s.TokenLifetime)})
+}
+
+func (s *ScriptProfile) UnmarshalJSON(data []byte) error {
+	type profile ScriptProfile
+	var decoded struct {
+		profile
+		SlaSec           utilities.DurationSeconds `json:"sla_sec"`
+		TokenLifetimeSec utilities.DurationSeconds `json:"token_lifetime_sec"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*s = ScriptProfile(decoded.profile)
+	s.Sla = decoded.SlaSec.Duration()
+	s.TokenLifetime = decoded.TokenLifetimeSec.Duration()
+	return nil
+}
+
+type ListScripts struct {
+	ProviderSelf struct {
+		ScriptGroup struct {
+			Scripts []GQLScriptProfile `graphql:"scripts(filter:$filter, limit:$limit, offset:$offset)"`
+		} `graphql:"script_group(id:$script_group_id)"`
+	} `graphql:"provider_self(id:$provider_id)"`
+}
+
+type ListAllScripts struct {
+	ProviderSelf struct {
+		Scripts []GQLScriptProfile `graphql:"scripts(filter:$filter, limit:$limit, offset:$offset)"`
+	} `graphql:"provider_self(id:$provider_id)"`
 }
 
 type EditScript struct {
@@ -107,12 +259,129 @@ type EditScript struct {
 			Script struct {
 				Edit struct {
 					Uuid uuid.UUID
-				} `graphql:"edit(changes:$changes)"`
+				} `graphql:"edit(changes:$changes, if_match:$if_match)"`
 			} `graphql:"script(id:$id)"`
 		} `graphql:"script_group(id:$script_group_id)"`
 	} `graphql:"provider(id:$provider_id)"`
 }
 
+type ArchiveScript struct {
+	Provider struct {
+		ScriptGroup struct {
+			Script struct {
+				Archive struct {
+					Uuid uuid.UUID
+				} `graphql:"archive"`
+			} `graphql:"script(id:$id)"`
+		} `graphql:"script_group(id:$script_group_id)"`
+	} `graphql:"provider(id:$provider_id)"`
+}
+
+type RestoreScript struct {
+	Provider struct {
+		ScriptGroup struct {
+			Script struct {
+				Restore struct {
+					Uuid uuid.UUID
+				} `graphql:"restore"`
+			} `graphql:"script(id:$id)"`
+		} `graphql:"script_group(id:$script_group_id)"`
+	} `graphql:"provider(id:$provider_id)"`
+}
+
+type DeleteScript struct {
+	Provider struct {
+		ScriptGroup struct {
+			Script struct {
+				Delete struct {
+					Uuid uuid.UUID
+				} `graphql:"delete"`
+			} `graphql:"script(id:$id)"`
+		} `graphql:"script_group(id:$script_group_id)"`
+	} `graphql:"provider(id:$provider_id)"`
+}
+
+type MoveScript struct {
+	Provider struct {
+		ScriptGroup struct {
+			Script struct {
+				Move struct {
+					Uuid uuid.UUID
+				} `graphql:"move(script_group_id:$target_script_group_id)"`
+			} `graphql:"script(id:$id)"`
+		} `graphql:"script_group(id:$script_group_id)"`
+	} `graphql:"provider(id:$provider_id)"`
+}
+
+type ScheduledPriceChange struct {
+	Uuid         uuid.UUID `graphql:"uuid" json:"uuid"`
+	PriceInCents uint      `graphql:"price_in_cents" json:"price_in_cents"`
+	EffectiveAt  time.Time `graphql:"effective_at" json:"effective_at"`
+	CreatedAt    time.Time `graphql:"created_at" json:"created_at"`
+}
+
+type PriceHistoryEntry struct {
+	PriceInCents uint      `graphql:"price_in_cents" json:"price_in_cents"`
+	EffectiveAt  time.Time `graphql:"effective_at" json:"effective_at"`
+	ChangedBy    *string   `graphql:"changed_by" json:"changed_by"`
+}
+
+type SchedulePriceChange struct {
+	Provider struct {
+		ScriptGroup struct {
+			Script struct {
+				PriceChanges struct {
+					Schedule ScheduledPriceChange `graphql:"schedule(price_in_cents:$price_in_cents, effective_at:$effective_at)"`
+				} `graphql:"price_changes"`
+			} `graphql:"script(id:$id)"`
+		} `graphql:"script_group(id:$script_group_id)"`
+	} `graphql:"provider(id:$provider_id)"`
+}
+
+type CancelScheduledPriceChange struct {
+	Provider struct {
+		ScriptGroup struct {
+			Script struct {
+				PriceChanges struct {
+					Cancel struct {
+						Uuid uuid.UUID
+					} `graphql:"cancel(id:$price_change_id)"`
+				} `graphql:"price_changes"`
+			} `graphql:"script(id:$id)"`
+		} `graphql:"script_group(id:$script_group_id)"`
+	} `graphql:"provider(id:$provider_id)"`
+}
+
+type GetScheduledPriceChanges struct {
+	ProviderSelf struct {
+		ScriptGroup struct {
+			Script struct {
+				ScheduledPriceChanges []ScheduledPriceChange `graphql:"scheduled_price_changes"`
+			} `graphql:"script(id:$id)"`
+		} `graphql:"script_group(id:$script_group_id)"`
+	} `graphql:"provider_self(id:$provider_id)"`
+}
+
+type GetPriceHistory struct {
+	ProviderSelf struct {
+		ScriptGroup struct {
+			Script struct {
+				PriceHistory []PriceHistoryEntry `graphql:"price_history"`
+			} `graphql:"script(id:$id)"`
+		} `graphql:"script_group(id:$script_group_id)"`
+	} `graphql:"provider_self(id:$provider_id)"`
+}
+
+type GetScriptActiveSubscriptions struct {
+	ProviderSelf struct {
+		ScriptGroup struct {
+			Script struct {
+				ActiveSubscriptions uint `graphql:"active_subscriptions"`
+			} `graphql:"script(id:$id)"`
+		} `graphql:"script_group(id:$script_group_id)"`
+	} `graphql:"provider_self(id:$provider_id)"`
+}
+
 type IssueSubscriberToken struct {
 	Provider struct {
 		Tokens struct {
@@ -141,7 +410,7 @@ type CreateNewScript struct {
 			Scripts struct {
 				Create struct {
 					Uuid uuid.UUID
-				} `graphql:"create(alt_id: $alt_id, name: $name, description: $description, price_in_cents: $price_in_cents, recurrence: $recurrence, sla_sec: $sla_sec, token_lifetime_sec: $token_lifetime_sec, public: $public)"`
+				} `graphql:"create(alt_id: $alt_id, name: $name, description: $description, price_in_cents: $price_in_cents, recurrence: $recurrence, sla_sec: $sla_sec, token_lifetime_sec: $token_lifetime_sec, public: $public, metadata: $metadata)"`
 			}
 		} `graphql:"script_group(id: $script_group_id)"`
 	} `graphql:"provider(id: $provider_id)"`
@@ -152,7 +421,7 @@ type CreateNewScriptGroup struct {
 		ScriptGroups struct {
 			Create struct {
 				Uuid uuid.UUID
-			} `graphql:"create(alt_id: $alt_id, name: $name, description: $description, public: $public)"`
+			} `graphql:"create(alt_id: $alt_id, name: $name, description: $description, public: $public, metadata: $metadata)"`
 		} `graphql:"script_groups"`
 	} `graphql:"provider(id: $provider_id)"`
 }
@@ -175,3 +444,122 @@ type CreateNewProvider struct {
 		} `graphql:"create(name: $name, description: $description, category_id: $category_id)"`
 	} `graphql:"providers"`
 }
+
+type ProviderMember struct {
+	UserUuid uuid.UUID `graphql:"user_uuid" json:"user_uuid"`
+	Email    string    `graphql:"email" json:"email"`
+	Name     *string   `graphql:"name" json:"name"`
+	Role     string    `graphql:"role" json:"role"`
+	// Status is (invited) until the user accepts, then (active)
+	Status    string     `graphql:"status" json:"status"`
+	InvitedAt time.Time  `graphql:"invited_at" json:"invited_at"`
+	JoinedAt  *time.Time `graphql:"joined_at" json:"joined_at"`
+}
+
+type ListProviderMembers struct {
+	ProviderSelf struct {
+		Members []ProviderMember `graphql:"members"`
+	} `graphql:"provider_self(id:$provider_id)"`
+}
+
+type InviteProviderMember struct {
+	Provider struct {
+		Members struct {
+			Invite ProviderMember `graphql:"invite(email:$email, role:$role)"`
+		} `graphql:"members"`
+	} `graphql:"provider(id:$provider_id)"`
+}
+
+type ChangeProviderMemberRole struct {
+	Provider struct {
+		Members struct {
+			Member struct {
+				ChangeRole ProviderMember `graphql:"change_role(role:$role)"`
+			} `graphql:"member(user_id:$user_id)"`
+		} `graphql:"members"`
+	} `graphql:"provider(id:$provider_id)"`
+}
+
+type RemoveProviderMember struct {
+	Provider struct {
+		Members struct {
+			Member struct {
+				Remove struct {
+					UserUuid uuid.UUID `graphql:"user_uuid"`
+				} `graphql:"remove"`
+			} `graphql:"member(user_id:$user_id)"`
+		} `graphql:"members"`
+	} `graphql:"provider(id:$provider_id)"`
+}
+
+type AccountServiceBranding struct {
+	// Title is shown at the top of the account screens, the provider name when unset
+	Title            *string `graphql:"title" json:"title"`
+	LogoUrl          *string `graphql:"logo_url" json:"logo_url"`
+	PrimaryColor     *string `graphql:"primary_color" json:"primary_color"`
+	SupportEmail     *string `graphql:"support_email" json:"support_email"`
+	PrivacyPolicyUrl *string `graphql:"privacy_policy_url" json:"privacy_policy_url"`
+	TermsUrl         *string `graphql:"terms_url" json:"terms_url"`
+}
+
+type AccountServiceConfig struct {
+	Enabled bool `graphql:"enabled" json:"enabled"`
+	// RedirectUrls are where users can be sent back to after signing in
+	RedirectUrls []string `graphql:"redirect_urls" json:"redirect_urls"`
+	// AllowedOrigins can embed the account screens and call the account api
+	AllowedOrigins []string               `graphql:"allowed_origins" json:"allowed_origins"`
+	Branding       AccountServiceBranding `graphql:"branding" json:"branding"`
+}
+
+type GetAccountService struct {
+	ProviderSelf struct {
+		AccountService AccountServiceConfig `graphql:"account_service"`
+	} `graphql:"provider_self(id:$provider_id)"`
+}
+
+type EditAccountService struct {
+	Provider struct {
+		AccountService struct {
+			Edit AccountServiceConfig `graphql:"edit(changes:$changes)"`
+		} `graphql:"account_service"`
+	} `graphql:"provider(id:$provider_id)"`
+}
+
+type SimulatedSubscription struct {
+	Uuid         uuid.UUID `graphql:"uuid" json:"uuid"`
+	SubscriberID string    `graphql:"subscriber_id" json:"subscriber_id"`
+	ScriptUuid   uuid.UUID `graphql:"script_uuid" json:"script_uuid"`
+	// Status is one of (active), (past_due) or (canceled)
+	Status           string    `graphql:"status" json:"status"`
+	CurrentPeriodEnd time.Time `graphql:"current_period_end" json:"current_period_end"`
+}
+
+type SimulateSubscription struct {
+	Provider struct {
+		Sandbox struct {
+			Subscriptions struct {
+				Simulate SimulatedSubscription `graphql:"simulate(script_group_id:$script_group_id, script_id:$script_id, subscriber_id:$subscriber_id)"`
+			} `graphql:"subscriptions"`
+		} `graphql:"sandbox"`
+	} `graphql:"provider(id:$provider_id)"`
+}
+
+type RenewSimulatedSubscription struct {
+	Provider struct {
+		Sandbox struct {
+			Subscriptions struct {
+				Renew SimulatedSubscription `graphql:"renew(id:$id, payment_succeeds:$payment_succeeds)"`
+			} `graphql:"subscriptions"`
+		} `graphql:"sandbox"`
+	} `graphql:"provider(id:$provider_id)"`
+}
+
+type CancelSimulatedSubscription struct {
+	Provider struct {
+		Sandbox struct {
+			Subscriptions struct {
+				Cancel SimulatedSubscription `graphql:"cancel(id:$id)"`
+			} `graphql:"subscriptions"`
+		} `graphql:"sandbox"`
+	} `graphql:"provider(id:$provider_id)"`
+}
@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+
+	"github.com/myscribae/myscribae-sdk-go/gql"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+// MaxAccountServiceUrls bounds the redirect urls and allowed origins of the account service
+const MaxAccountServiceUrls = 20
+
+// UpdateAccountServiceInput changes the set fields of the account service.
+// RedirectUrls and AllowedOrigins replace the whole list.  Every branding
+// field can be cleared with utilities.NullOptional.
+type UpdateAccountServiceInput struct {
+	Enabled        utilities.Optional[bool]          `json:"enabled"`
+	RedirectUrls   utilities.Optional[[]string]      `json:"redirect_urls"`
+	AllowedOrigins utilities.Optional[[]string]      `json:"allowed_origins"`
+	Branding       UpdateAccountServiceBrandingInput `json:"branding"`
+}
+
+type UpdateAccountServiceBrandingInput struct {
+	Title            utilities.Optional[string] `json:"title"`
+	LogoUrl          utilities.Optional[string] `json:"logo_url"`
+	PrimaryColor     utilities.Optional[string] `json:"primary_color"`
+	SupportEmail     utilities.Optional[string] `json:"support_email"`
+	PrivacyPolicyUrl utilities.Optional[string] `json:"privacy_policy_url"`
+	TermsUrl         utilities.Optional[string] `json:"terms_url"`
+}
+
+// IsEmpty reports whether the update changes nothing
+func (ai *UpdateAccountServiceInput) IsEmpty() bool {
+	return !ai.Enabled.IsSet() &&
+		!ai.RedirectUrls.IsSet() &&
+		!ai.AllowedOrigins.IsSet() &&
+		ai.Branding.IsEmpty()
+}
+
+// IsEmpty reports whether the update changes nothing
+func (bi *UpdateAccountServiceBrandingInput) IsEmpty() bool {
+	return !bi.Title.IsSet() &&
+		!bi.LogoUrl.IsSet() &&
+		!bi.PrimaryColor.IsSet() &&
+		!bi.SupportEmail.IsSet() &&
+		!bi.PrivacyPolicyUrl.IsSet() &&
+		!bi.TermsUrl.IsSet()
+}
+
+func (ai *UpdateAccountServiceInput) MarshalJSON() ([]byte, error) {
+	// only marshal set fields, with the branding changes nested under branding
+	data := make(map[string]interface{})
+	if err := errors.Join(
+		setChange(data, "enabled", ai.Enabled, false),
+		setChange(data, "redirect_urls", ai.RedirectUrls, false),
+		setChange(data, "allowed_origins", ai.AllowedOrigins, false),
+	); err != nil {
+		return nil, err
+	}
+
+	if !ai.Branding.IsEmpty() {
+		branding := make(map[string]interface{})
+		if err := errors.Join(
+			setChange(branding, "title", ai.Branding.Title, true),
+			setChange(branding, "logo_url", ai.Branding.LogoUrl, true),
+			setChange(branding, "primary_color", ai.Branding.PrimaryColor, true),
+			setChange(branding, "support_email", ai.Branding.SupportEmail, true),
+			setChange(branding, "privacy_policy_url", ai.Branding.PrivacyPolicyUrl, true),
+			setChange(branding, "terms_url", ai.Branding.TermsUrl, true),
+		); err != nil {
+			return nil, err
+		}
+		data["branding"] = branding
+	}
+
+	return json.Marshal(data)
+}
+
+// Validate checks the set fields of the input before it is sent to the api,
+// returning a *ValidationError with every invalid field
+func (ai *UpdateAccountServiceInput) Validate() error {
+	var v validator
+	optional(&v, "enabled", ai.Enabled, false, func(bool) {})
+	optional(&v, "redirect_urls", ai.RedirectUrls, false, func(urls []string) {
+		v.list("redirect_urls", urls, v.url)
+	})
+	optional(&v, "allowed_origins", ai.AllowedOrigins, false, func(origins []string) {
+		v.list("allowed_origins", origins, v.origin)
+	})
+
+	branding := ai.Branding
+	optional(&v, "branding.title", branding.Title, true, func(title string) {
+		v.text("branding.title", title, true, MaxNameLength)
+	})
+	optional(&v, "branding.logo_url", branding.LogoUrl, true, func(logo string) { v.url("branding.logo_url", logo) })
+	optional(&v, "branding.primary_color", branding.PrimaryColor, true, func(color string) {
+		v.color("branding.primary_color", color)
+	})
+	optional(&v, "branding.support_email", branding.SupportEmail, true, func(email string) {
+		v.email("branding.support_email", email)
+	})
+	optional(&v, "branding.privacy_policy_url", branding.PrivacyPolicyUrl, true, func(u string) {
+		v.url("branding.privacy_policy_url", u)
+	})
+	optional(&v, "branding.terms_url", branding.TermsUrl, true, func(u string) { v.url("branding.terms_url", u) })
+	return v.err()
+}
+
+// list checks each value of a url list, reporting them as field[i]
+func (v *validator) list(field string, values []string, check func(field, value string)) {
+	if len(values) > MaxAccountServiceUrls {
+		v.add(field, "must have at most %d entries", MaxAccountServiceUrls)
+	}
+
+	for i, value := range values {
+		check(fmt.Sprintf("%s[%d]", field, i), value)
+	}
+}
+
+// origin checks a value is a bare origin like (https://example.com:8080),
+// without a path, query or fragment
+func (v *validator) origin(field, value string) {
+	parsed, err := url.Parse(value)
+	switch {
+	case err != nil || parsed.Host == "":
+		v.add(field, "must be an origin like (https://example.com)")
+	case parsed.Scheme != "http" && parsed.Scheme != "https":
+		v.add(field, "must be an http or https origin")
+	case (parsed.Path != "" && parsed.Path != "/") || parsed.RawQuery != "" || parsed.Fragment != "" || parsed.User != nil:
+		v.add(field, "must be an origin without a path, like (https://example.com)")
+	}
+}
+
+func (v *validator) email(field, value string) {
+	if _, err := mail.ParseAddress(value); err != nil {
+		v.add(field, "must be an email address")
+	}
+}
+
+// AccountService reads the account service configuration of the provider
+func (p *Provider) AccountService(ctx context.Context) (*gql.AccountServiceConfig, error) {
+	var query gql.GetAccountService
+	if err := p.Client.Query(ctx, &query, map[string]interface{}{
+		"provider_id": p.ID(),
+	}); err != nil {
+		return nil, permissionError(err, PermissionRead)
+	}
+
+	return &query.ProviderSelf.AccountService, nil
+}
+
+// UpdateAccountService sends the changed fields of the account service
+// configuration and returns the configuration after the update, so it can
+// be enabled and configured in one call
+func (p *Provider) UpdateAccountService(ctx context.Context, input UpdateAccountServiceInput) (*gql.AccountServiceConfig, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	changes, err := input.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var mutation gql.EditAccountService
+	if err := p.Client.Mutate(ctx, &mutation, map[string]interface{}{
+		"provider_id": p.ID(),
+		"changes":     string(changes),
+	}); err != nil {
+		return nil, permissionError(err, PermissionManageProfile)
+	}
+
+	return &mutation.Provider.AccountService.Edit, nil
+}
@@ -0,0 +1,75 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/myscribae/myscribae-sdk-go/provider"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func TestUpdateAccountServiceChanges(t *testing.T) {
+	input := provider.UpdateAccountServiceInput{
+		Enabled:      utilities.NewOptional(true),
+		RedirectUrls: utilities.NewOptional([]string{"https://example.com/callback"}),
+		Branding: provider.UpdateAccountServiceBrandingInput{
+			PrimaryColor: utilities.NewOptional("#1a2b3c"),
+			TermsUrl:     utilities.NullOptional[string](),
+		},
+	}
+
+	changes, err := input.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(changes, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"enabled":       true,
+		"redirect_urls": []interface{}{"https://example.com/callback"},
+		"branding": map[string]interface{}{
+			"primary_color": "#1a2b3c",
+			"terms_url":     nil,
+		},
+	}
+	if !reflect.DeepEqual(decoded, expected) {
+		t.Errorf("expected %v, got %v", expected, decoded)
+	}
+
+	if !(&provider.UpdateAccountServiceInput{}).IsEmpty() || input.IsEmpty() {
+		t.Error("expected only the zero input to be empty")
+	}
+}
+
+func TestValidateAccountService(t *testing.T) {
+	input := provider.UpdateAccountServiceInput{
+		Enabled:      utilities.NullOptional[bool](),
+		RedirectUrls: utilities.NewOptional([]string{"https://example.com/callback", "example.com"}),
+		AllowedOrigins: utilities.NewOptional([]string{
+			"https://example.com",
+			"http://localhost:3000/",
+			"https://example.com/app",
+			"ftp://example.com",
+		}),
+		Branding: provider.UpdateAccountServiceBrandingInput{
+			SupportEmail: utilities.NewOptional("support"),
+			Title:        utilities.NullOptional[string](),
+		},
+	}
+
+	expected := []string{
+		"enabled",
+		"redirect_urls[1]",
+		"allowed_origins[2]",
+		"allowed_origins[3]",
+		"branding.support_email",
+	}
+	if fields := violatedFields(t, input.Validate()); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("expected violations on %v, got %v", expected, fields)
+	}
+}
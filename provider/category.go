@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/myscribae/myscribae-sdk-go/general"
+	"github.com/myscribae/myscribae-sdk-go/gql"
+)
+
+// Categories lists the categories the provider can be in, cached after the first call
+func (p *Provider) Categories(ctx context.Context) ([]gql.ProviderCategory, error) {
+	return p.myscribae().ProviderCategories(ctx)
+}
+
+// myscribae returns the general api on the provider's client, keeping its cache
+func (p *Provider) myscribae() *general.MyScribae {
+	p.generalMu.Lock()
+	defer p.generalMu.Unlock()
+
+	if p.general == nil {
+		p.general = general.NewMyScribae(p.Client)
+	}
+
+	return p.general
+}
+
+// validateCategory checks the category exists, returning a *ValidationError
+// for unknown categories
+func validateCategory(ctx context.Context, m *general.MyScribae, category string) error {
+	_, err := m.ProviderCategory(ctx, category)
+	if errors.Is(err, general.ErrUnknownCategory) {
+		var v validator
+		v.add("category_id", "is not a known category: %s", category)
+		return v.err()
+	}
+
+	return err
+}
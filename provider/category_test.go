@@ -0,0 +1,88 @@
+package provider_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hasura/go-graphql-client"
+	"github.com/myscribae/myscribae-sdk-go/provider"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func TestCreateNewProviderValidatesCategory(t *testing.T) {
+	var creates int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if bytes.Contains(body, []byte("provider_categories")) {
+			w.Write([]byte(`{"data":{"provider_categories":[{"id":"writing","name":"Writing","parent_id":null}]}}`))
+			return
+		}
+
+		creates++
+		w.Write([]byte(`{"data":{"providers":{"create":{"uuid":"00000000-0000-0000-0000-000000000000"}}}}`))
+	}))
+	defer server.Close()
+
+	category := "poetry"
+	_, err := provider.CreateNewProvider(context.Background(), graphql.NewClient(server.URL, nil), &provider.CreateProviderProfileInput{
+		Name:       "Provider",
+		CategoryID: &category,
+	})
+	if fields := violatedFields(t, err); !reflect.DeepEqual(fields, []string{"category_id"}) {
+		t.Errorf("expected an unknown category violation, got %v", err)
+	}
+	if creates != 0 {
+		t.Errorf("expected the create mutation not to be sent, got %d", creates)
+	}
+}
+
+func TestUpdateValidatesCategory(t *testing.T) {
+	var categoryRequests, editRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if bytes.Contains(body, []byte("provider_categories")) {
+			categoryRequests++
+			w.Write([]byte(`{"data":{"provider_categories":[
+				{"id":"writing","name":"Writing","parent_id":null},
+				{"id":"copywriting","name":"Copywriting","parent_id":"writing"}
+			]}}`))
+			return
+		}
+
+		editRequests++
+		w.Write([]byte(`{"data":{"provider":{"edit":{"uuid":"00000000-0000-0000-0000-000000000000"}}}}`))
+	}))
+	defer server.Close()
+
+	prov := &provider.Provider{Client: graphql.NewClient(server.URL, nil)}
+
+	_, err := prov.Update(context.Background(), provider.UpdateProviderProfileInput{
+		CategoryID: utilities.NewOptional("poetry"),
+	})
+	if fields := violatedFields(t, err); !reflect.DeepEqual(fields, []string{"category_id"}) {
+		t.Errorf("expected an unknown category violation, got %v", err)
+	}
+
+	if _, err := prov.Update(context.Background(), provider.UpdateProviderProfileInput{
+		CategoryID: utilities.NewOptional("copywriting"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	categories, err := prov.Categories(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(categories) != 2 || categories[1].ParentID == nil || *categories[1].ParentID != "writing" {
+		t.Errorf("unexpected categories %+v", categories)
+	}
+
+	if categoryRequests != 1 || editRequests != 1 {
+		t.Errorf("expected the categories to be fetched once and one edit, got %d and %d", categoryRequests, editRequests)
+	}
+}
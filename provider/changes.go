@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+// setChange adds a set optional to the changes of an update.  A null
+// optional is sent as an explicit null, which clears the field, when the
+// field is nullable.
+func setChange[T any](changes map[string]interface{}, field string, value utilities.Optional[T], nullable bool) error {
+	if !value.IsSet() {
+		return nil
+	}
+
+	if value.IsNull() {
+		if !nullable {
+			return fmt.Errorf("%w: %s", ErrNotNullable, field)
+		}
+		changes[field] = nil
+		return nil
+	}
+
+	changes[field], _ = value.Get()
+	return nil
+}
+
+// durationSeconds converts a duration change to the whole seconds the api expects
+func durationSeconds(value utilities.Optional[time.Duration]) utilities.Optional[utilities.DurationSeconds] {
+	if d, ok := value.Get(); ok {
+		return utilities.NewOptional(utilities.DurationSeconds(d))
+	}
+	if value.IsNull() {
+		return utilities.NullOptional[utilities.DurationSeconds]()
+	}
+	return utilities.Optional[utilities.DurationSeconds]{}
+}
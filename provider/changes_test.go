@@ -0,0 +1,42 @@
+package provider_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/myscribae/myscribae-sdk-go/provider"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func TestUpdateInputClearsFields(t *testing.T) {
+	input := provider.UpdateProviderProfileInput{
+		Name:    utilities.NewOptional("New Name"),
+		LogoUrl: utilities.NullOptional[string](),
+	}
+
+	data, err := input.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `{"logo_url":null,"name":"New Name"}` {
+		t.Errorf("unexpected changes %s", data)
+	}
+
+	for name, input := range map[string]provider.UpdateProviderProfileInput{
+		"name":   {Name: utilities.NullOptional[string]()},
+		"alt_id": {AltID: utilities.NullOptional[string]()},
+	} {
+		if _, err := input.MarshalJSON(); !errors.Is(err, provider.ErrNotNullable) {
+			t.Errorf("%s: expected not nullable error, got %v", name, err)
+		}
+	}
+
+	scriptInput := provider.UpdateScriptInput{
+		PriceInCents: utilities.NullOptional[utilities.MoneyValue](),
+	}
+
+	if _, err := scriptInput.MarshalJSON(); !errors.Is(err, provider.ErrNotNullable) {
+		t.Errorf("expected not nullable error, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/myscribae/myscribae-sdk-go/gql"
+)
+
+// MaxConflictRetries is how many times the ReadModifyWrite helpers retry
+// after a conflicting concurrent update
+const MaxConflictRetries = 3
+
+// IsEmpty reports whether the update changes nothing
+func (pi *UpdateProviderProfileInput) IsEmpty() bool {
+	return !pi.AltID.IsSet() &&
+		!pi.Name.IsSet() &&
+		!pi.CategoryID.IsSet() &&
+		!pi.Description.IsSet() &&
+		!pi.LogoUrl.IsSet() &&
+		!pi.BannerUrl.IsSet() &&
+		!pi.Url.IsSet() &&
+		!pi.Color.IsSet() &&
+		!pi.Public.IsSet() &&
+		!pi.AccountService.IsSet()
+}
+
+// conditionalUpdate is an update input that can be sent conditionally on
+// the version that was read
+type conditionalUpdate[U any] interface {
+	*U
+	IsEmpty() bool
+	ifMatch(version string)
+}
+
+func (pi *UpdateProviderProfileInput) ifMatch(version string) { pi.IfMatch = &version }
+func (sgi *UpdateScriptGroupInput) ifMatch(version string)    { sgi.IfMatch = &version }
+func (si *UpdateScriptInput) ifMatch(version string)          { si.IfMatch = &version }
+
+// readModifyWrite reads the current state, passes it to modify and writes
+// the returned changes conditionally on the version that was read.  The whole
+// cycle is retried up to retries times while the write fails with a conflict.
+// An empty update skips the write and returns the id that was read.
+func readModifyWrite[C any, U any, PU conditionalUpdate[U]](
+	ctx context.Context,
+	retries int,
+	read func(ctx context.Context) (current *C, id uuid.UUID, version string, err error),
+	modify func(current *C) (U, error),
+	write func(ctx context.Context, update U) (*uuid.UUID, error),
+) (*uuid.UUID, error) {
+	var conflict error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		current, id, version, err := read(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		update, err := modify(current)
+		if err != nil {
+			return nil, err
+		}
+
+		if PU(&update).IsEmpty() {
+			return &id, nil
+		}
+
+		PU(&update).ifMatch(version)
+		written, err := write(ctx, update)
+		if !errors.Is(err, ErrConflict) {
+			return written, err
+		}
+		conflict = err
+	}
+
+	return nil, fmt.Errorf("gave up after %d conflicting updates: %w", retries+1, conflict)
+}
+
+// ReadModifyWrite reads the provider profile, passes it to modify and sends
+// the returned changes conditionally on the version that was read.  On a
+// conflicting concurrent update the whole cycle is retried, up to
+// MaxConflictRetries times.  An empty update skips the write.
+func (p *Provider) ReadModifyWrite(
+	ctx context.Context,
+	modify func(current *gql.ProviderProfile) (UpdateProviderProfileInput, error),
+) (*uuid.UUID, error) {
+	read := func(ctx context.Context) (*gql.ProviderProfile, uuid.UUID, string, error) {
+		current, err := p.Read(ctx)
+		if err != nil {
+			return nil, uuid.Nil, "", err
+		}
+		return current, current.Uuid, current.Version, nil
+	}
+
+	return readModifyWrite(ctx, MaxConflictRetries, read, modify, p.Update)
+}
+
+// ReadModifyWrite reads the script group, passes it to modify and sends the
+// returned changes conditionally on the version that was read.  On a
+// conflicting concurrent update the whole cycle is retried, up to
+// MaxConflictRetries times.  An empty update skips the write.
+func (sg *ScriptGroup) ReadModifyWrite(
+	ctx context.Context,
+	modify func(current *gql.ScriptGroupProfile) (UpdateScriptGroupInput, error),
+) (*uuid.UUID, error) {
+	read := func(ctx context.Context) (*gql.ScriptGroupProfile, uuid.UUID, string, error) {
+		current, err := sg.Read(ctx)
+		if err != nil {
+			return nil, uuid.Nil, "", err
+		}
+		return current, current.Uuid, current.Version, nil
+	}
+
+	return readModifyWrite(ctx, MaxConflictRetries, read, modify, sg.Update)
+}
+
+// ReadModifyWrite reads the script, passes it to modify and sends the
+// returned changes conditionally on the version that was read.  On a
+// conflicting concurrent update the whole cycle is retried, up to
+// MaxConflictRetries times.  An empty update skips the write.
+func (s *Script) ReadModifyWrite(
+	ctx context.Context,
+	modify func(current *gql.ScriptProfile) (UpdateScriptInput, error),
+) (*uuid.UUID, error) {
+	read := func(ctx context.Context) (*gql.ScriptProfile, uuid.UUID, string, error) {
+		current, err := s.Read(ctx)
+		if err != nil {
+			return nil, uuid.Nil, "", err
+		}
+		return current, current.Uuid, current.Version, nil
+	}
+
+	return readModifyWrite(ctx, MaxConflictRetries, read, modify, s.Update)
+}
@@ -0,0 +1,45 @@
+package provider_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hasura/go-graphql-client"
+	"github.com/myscribae/myscribae-sdk-go/gql"
+	"github.com/myscribae/myscribae-sdk-go/provider"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func TestReadModifyWriteGivesUp(t *testing.T) {
+	var reads, writes int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if bytes.Contains(body, []byte("provider_self")) {
+			reads++
+			w.Write([]byte(`{"data":{"provider_self":{"uuid":"00000000-0000-0000-0000-000000000001","version":"v1"}}}`))
+			return
+		}
+
+		writes++
+		w.Write([]byte(`{"errors":[{"message":"modified","extensions":{"code":"CONFLICT"}}]}`))
+	}))
+	defer server.Close()
+
+	prov := &provider.Provider{Client: graphql.NewClient(server.URL, nil)}
+	_, err := prov.ReadModifyWrite(context.Background(), func(current *gql.ProviderProfile) (provider.UpdateProviderProfileInput, error) {
+		return provider.UpdateProviderProfileInput{Public: utilities.NewOptional(!current.Public)}, nil
+	})
+
+	var conflictErr *provider.ConflictError
+	if !errors.As(err, &conflictErr) || conflictErr.IfMatch != "v1" {
+		t.Errorf("expected the last conflict, got %v", err)
+	}
+	if reads != provider.MaxConflictRetries+1 || writes != provider.MaxConflictRetries+1 {
+		t.Errorf("expected %d read and write cycles, got %d reads and %d writes", provider.MaxConflictRetries+1, reads, writes)
+	}
+}
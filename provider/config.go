@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/myscribae/myscribae-sdk-go/environment"
+)
+
+var ErrUnknownProfile = errors.New("unknown config profile")
+
+// ConfigSource describes where a config value came from, for example
+// (env MYSCRIBAE_API_KEY) or (file myscribae.yaml, profile sandbox)
+type ConfigSource string
+
+const (
+	SourceDefault  ConfigSource = "default"
+	SourceExplicit ConfigSource = "explicit"
+)
+
+type configField struct {
+	key    string
+	envVar string
+	secret bool
+}
+
+// configFields are the loaded values, in the order they are reported
+var configFields = []configField{
+	{"environment", environment.EnvironmentEnvVar, false},
+	{"api_url", environment.ApiUrlEnvVar, false},
+	{"api_key", environment.ApiKeyEnvVar, true},
+	{"secret_key", environment.SecretKeyEnvVar, true},
+	{"api_token", environment.ApiTokenEnvVar, true},
+}
+
+func configFieldByKey(key string) (configField, bool) {
+	for _, field := range configFields {
+		if field.key == key {
+			return field, true
+		}
+	}
+	return configField{}, false
+}
+
+func configFieldByEnvVar(envVar string) (configField, bool) {
+	for _, field := range configFields {
+		if field.envVar == envVar {
+			return field, true
+		}
+	}
+	return configField{}, false
+}
+
+// ConfigSetting is a loaded value and the source that supplied it.  Secrets
+// are masked when the setting is printed.
+type ConfigSetting struct {
+	Key    string
+	Value  string
+	Source ConfigSource
+	Secret bool
+}
+
+// Masked returns the value with secrets hidden, keeping the environment
+// prefix and last 4 characters of long enough keys, like (live_****a1b2)
+func (s ConfigSetting) Masked() string {
+	if !s.Secret {
+		return s.Value
+	}
+
+	var prefix string
+	if issuer, ok := environment.KeyEnvironment(s.Value); ok {
+		env, _ := environment.Named(issuer)
+		prefix = env.KeyPrefix
+	}
+
+	rest := strings.TrimPrefix(s.Value, prefix)
+	if len(rest) < 12 {
+		return prefix + "****"
+	}
+	return prefix + "****" + rest[len(rest)-4:]
+}
+
+func (s ConfigSetting) String() string {
+	return fmt.Sprintf("%s = %s (%s)", s.Key, s.Masked(), s.Source)
+}
+
+// ConfigOptions are the inputs of LoadConfig
+type ConfigOptions struct {
+	// File is the config file to load, falling back to MYSCRIBAE_CONFIG.
+	// No file is loaded when neither is set.
+	File string
+	// Profile selects a profile of the file, falling back to
+	// MYSCRIBAE_PROFILE and then to the file's top level profile key.
+	// Profile names are not case sensitive.
+	Profile string
+	// Explicit fields take precedence over every other source
+	Explicit ProviderConfig
+}
+
+// LoadedConfig is the merged configuration, with the source of every value
+type LoadedConfig struct {
+	// File and Profile are the config file and profile that were loaded, if
+	// any.  Profile is lowercased.
+	File        string
+	Profile     string
+	Environment environment.Environment
+
+	settings map[string]ConfigSetting
+}
+
+// LoadConfig merges the configuration from, lowest precedence first:
+//
+//  1. defaults: the environment of an api url given alone, custom unless it
+//     is the production or sandbox url, and the api url of a named
+//     environment.  Without either ErrMissingApiUrl is returned.
+//  2. the top level values of the config file
+//  3. the values of the selected profile of the config file
+//  4. the MYSCRIBAE_ environment variables
+//  5. the explicit fields of options.Explicit
+//
+// Config files are yaml, toml or dotenv.  Yaml and toml files have the keys
+// environment, api_url, api_key, secret_key, api_token and profile, and a
+// profiles table of named profiles with the same keys except profile.
+// Dotenv files use the environment variable names, with profile values
+// named like (MYSCRIBAE_PROFILE_SANDBOX_API_KEY).  Profile names are compared
+// lowercased in every format, so (Sandbox) selects the sandbox profile.
+func LoadConfig(options ConfigOptions) (*LoadedConfig, error) {
+	config := &LoadedConfig{settings: make(map[string]ConfigSetting)}
+	set := func(key, value string, source ConfigSource) {
+		if value == "" {
+			return
+		}
+		field, _ := configFieldByKey(key)
+		config.settings[key] = ConfigSetting{Key: key, Value: value, Source: source, Secret: field.secret}
+	}
+
+	config.File = options.File
+	if config.File == "" {
+		config.File = os.Getenv(environment.ConfigFileEnvVar)
+	}
+
+	if config.File != "" {
+		file, err := readConfigFile(config.File)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, value := range file.values {
+			if key != "profile" {
+				set(key, value, ConfigSource("file "+config.File))
+			}
+		}
+
+		config.Profile = options.Profile
+		if config.Profile == "" {
+			config.Profile = os.Getenv(environment.ProfileEnvVar)
+		}
+		if config.Profile == "" {
+			config.Profile = file.values["profile"]
+		}
+		config.Profile = strings.ToLower(config.Profile)
+
+		profiles := make(map[string]map[string]string, len(file.profiles))
+		for name, profile := range file.profiles {
+			lower := strings.ToLower(name)
+			if _, ok := profiles[lower]; ok {
+				return nil, fmt.Errorf("%w: %s: profile %s is defined more than once", ErrInvalidConfigFile, config.File, lower)
+			}
+			profiles[lower] = profile
+		}
+
+		if config.Profile != "" {
+			profile, ok := profiles[config.Profile]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s is not a profile of %s", ErrUnknownProfile, config.Profile, config.File)
+			}
+			for key, value := range profile {
+				set(key, value, ConfigSource(fmt.Sprintf("file %s, profile %s", config.File, config.Profile)))
+			}
+		}
+	} else if options.Profile != "" {
+		return nil, fmt.Errorf("%w: %s, no config file was given", ErrUnknownProfile, options.Profile)
+	}
+
+	for _, field := range configFields {
+		set(field.key, os.Getenv(field.envVar), ConfigSource("env "+field.envVar))
+	}
+
+	explicit := options.Explicit
+	for key, value := range map[string]*string{
+		"api_url":    explicit.ApiUrl,
+		"api_key":    explicit.ApiKey,
+		"secret_key": explicit.SecretKey,
+		"api_token":  explicit.ApiToken,
+	} {
+		if value != nil {
+			set(key, *value, SourceExplicit)
+		}
+	}
+	if explicit.Environment != nil {
+		set("environment", string(*explicit.Environment), SourceExplicit)
+	}
+
+	env, err := environment.Resolve(
+		environment.Name(config.settings["environment"].Value),
+		config.settings["api_url"].Value,
+	)
+	if err != nil {
+		return nil, err
+	}
+	config.Environment = env
+
+	if _, ok := config.settings["environment"]; !ok {
+		set("environment", string(env.Name), SourceDefault)
+	}
+	if _, ok := config.settings["api_url"]; !ok {
+		set("api_url", env.ApiUrl, SourceDefault)
+	}
+
+	return config, nil
+}
+
+// Setting returns the loaded value of a key like (api_key), and whether any source supplied it
+func (c *LoadedConfig) Setting(key string) (ConfigSetting, bool) {
+	setting, ok := c.settings[key]
+	return setting, ok
+}
+
+// Settings lists every loaded value, in a fixed order
+func (c *LoadedConfig) Settings() []ConfigSetting {
+	var settings []ConfigSetting
+	for _, field := range configFields {
+		if setting, ok := c.settings[field.key]; ok {
+			settings = append(settings, setting)
+		}
+	}
+
+	return settings
+}
+
+// String reports every loaded value and its source, one per line, with secrets masked
+func (c *LoadedConfig) String() string {
+	settings := c.Settings()
+	lines := make([]string, len(settings))
+	for i, setting := range settings {
+		lines[i] = setting.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// ProviderConfig returns the loaded values for InitializeProvider
+func (c *LoadedConfig) ProviderConfig() ProviderConfig {
+	value := func(key string) *string {
+		if setting, ok := c.settings[key]; ok {
+			return &setting.Value
+		}
+		return nil
+	}
+
+	name := c.Environment.Name
+	return ProviderConfig{
+		ApiKey:      value("api_key"),
+		SecretKey:   value("secret_key"),
+		ApiToken:    value("api_token"),
+		ApiUrl:      value("api_url"),
+		Environment: &name,
+	}
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"github.com/myscribae/myscribae-sdk-go/environment"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrInvalidConfigFile = errors.New("invalid config file")
+
+// configFile is the values of a config file, at the top level and per profile
+type configFile struct {
+	values   map[string]string
+	profiles map[string]map[string]string
+}
+
+// readConfigFile parses a config file by its extension: (.yaml), (.yml),
+// (.toml) or (.env).  A file named (.env) is a dotenv file too.
+func readConfigFile(path string) (*configFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file *configFile
+	switch ext := filepath.Ext(path); {
+	case ext == ".yaml" || ext == ".yml":
+		file, err = parseYAMLConfig(data)
+	case ext == ".toml":
+		file, err = parseTOMLConfig(data)
+	case ext == ".env" || filepath.Base(path) == ".env":
+		file, err = parseDotenvConfig(data)
+	default:
+		return nil, fmt.Errorf("%w: %s.  expecting a .yaml, .yml, .toml or .env file", ErrInvalidConfigFile, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %s", ErrInvalidConfigFile, path, err)
+	}
+
+	return file, nil
+}
+
+// fromRaw reads the values and profiles of a decoded yaml or toml file,
+// refusing unknown keys so typos are not silently ignored
+func fromRaw(raw map[string]interface{}) (*configFile, error) {
+	file := &configFile{values: make(map[string]string), profiles: make(map[string]map[string]string)}
+
+	for key, value := range raw {
+		if key != "profiles" {
+			if err := setConfigValue(file.values, key, value, true); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		profiles, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("profiles must be a table of profiles")
+		}
+		for name, profileRaw := range profiles {
+			profile, ok := profileRaw.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("profiles.%s must be a table of values", name)
+			}
+
+			file.profiles[name] = make(map[string]string)
+			for key, value := range profile {
+				if err := setConfigValue(file.profiles[name], key, value, false); err != nil {
+					return nil, fmt.Errorf("profiles.%s.%s", name, err)
+				}
+			}
+		}
+	}
+
+	return file, nil
+}
+
+func setConfigValue(values map[string]string, key string, value interface{}, topLevel bool) error {
+	if _, ok := configFieldByKey(key); !ok && !(topLevel && key == "profile") {
+		return fmt.Errorf("%s is not a config key", key)
+	}
+
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return fmt.Errorf("%s must be a single value", key)
+	case nil:
+		return nil
+	}
+
+	values[key] = fmt.Sprint(value)
+	return nil
+}
+
+func parseYAMLConfig(data []byte) (*configFile, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	return fromRaw(raw)
+}
+
+func parseTOMLConfig(data []byte) (*configFile, error) {
+	var raw map[string]interface{}
+	if err := toml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	return fromRaw(raw)
+}
+
+// dotenvProfilePrefix starts the keys of a profile in a dotenv file, for
+// example (MYSCRIBAE_PROFILE_SANDBOX_API_KEY) is the api_key of the sandbox profile
+const dotenvProfilePrefix = "MYSCRIBAE_PROFILE_"
+
+// parseDotenvConfig reads the MYSCRIBAE_ variables of a dotenv file, other
+// variables are ignored
+func parseDotenvConfig(data []byte) (*configFile, error) {
+	vars, err := godotenv.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	file := &configFile{values: make(map[string]string), profiles: make(map[string]map[string]string)}
+
+	// sort so the longest env var suffix is matched first
+	fields := append([]configField(nil), configFields...)
+	sort.Slice(fields, func(i, j int) bool { return len(fields[i].envVar) > len(fields[j].envVar) })
+
+	for name, value := range vars {
+		if field, ok := configFieldByEnvVar(name); ok {
+			file.values[field.key] = value
+			continue
+		}
+		if name == environment.ProfileEnvVar {
+			file.values["profile"] = value
+			continue
+		}
+
+		rest, ok := strings.CutPrefix(name, dotenvProfilePrefix)
+		if !ok {
+			continue
+		}
+		for _, field := range fields {
+			suffix := "_" + strings.TrimPrefix(field.envVar, "MYSCRIBAE_")
+			if profile, ok := strings.CutSuffix(rest, suffix); ok && profile != "" {
+				if file.profiles[profile] == nil {
+					file.profiles[profile] = make(map[string]string)
+				}
+				file.profiles[profile][field.key] = value
+				break
+			}
+		}
+	}
+
+	return file, nil
+}
@@ -0,0 +1,197 @@
+package provider_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/myscribae/myscribae-sdk-go/environment"
+	"github.com/myscribae/myscribae-sdk-go/provider"
+)
+
+// clearConfigEnv unsets the MYSCRIBAE_ variables for the test
+func clearConfigEnv(t *testing.T) {
+	t.Helper()
+
+	for _, envVar := range []string{
+		environment.EnvironmentEnvVar,
+		environment.ApiUrlEnvVar,
+		environment.ApiKeyEnvVar,
+		environment.SecretKeyEnvVar,
+		environment.ApiTokenEnvVar,
+		environment.ConfigFileEnvVar,
+		environment.ProfileEnvVar,
+	} {
+		t.Setenv(envVar, "")
+	}
+}
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigFormats(t *testing.T) {
+	files := map[string]string{
+		"myscribae.yaml": `
+api_key: live_toplevel
+profile: sandbox
+profiles:
+  sandbox:
+    environment: sandbox
+    api_key: test_0123456789abcd
+`,
+		"myscribae.toml": `
+api_key = "live_toplevel" # the production key
+profile = 'sandbox'
+
+[profiles.sandbox]
+environment = "sandbox"
+api_key = "test_0123456789abcd"
+`,
+		".env": `
+MYSCRIBAE_API_KEY=live_toplevel
+MYSCRIBAE_PROFILE=sandbox
+MYSCRIBAE_PROFILE_SANDBOX_ENVIRONMENT=sandbox
+MYSCRIBAE_PROFILE_SANDBOX_API_KEY=test_0123456789abcd
+UNRELATED=value
+`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			clearConfigEnv(t)
+			path := writeConfig(t, name, content)
+
+			config, err := provider.LoadConfig(provider.ConfigOptions{File: path})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if config.Profile != "sandbox" || config.Environment != environment.SandboxEnvironment {
+				t.Errorf("expected the sandbox profile and environment, got %s and %s", config.Profile, config.Environment)
+			}
+
+			apiKey, _ := config.Setting("api_key")
+			if apiKey.Value != "test_0123456789abcd" || apiKey.Source != provider.ConfigSource("file "+path+", profile sandbox") {
+				t.Errorf("expected the profile api key, got %s", apiKey)
+			}
+		})
+	}
+}
+
+func TestLoadConfigProfileCase(t *testing.T) {
+	files := map[string]string{
+		"myscribae.yaml": "profiles:\n  SandBox:\n    environment: sandbox\n",
+		"myscribae.toml": "[profiles.SandBox]\nenvironment = \"sandbox\"\n",
+		".env":           "MYSCRIBAE_PROFILE_SANDBOX_ENVIRONMENT=sandbox\n",
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			clearConfigEnv(t)
+			t.Setenv(environment.ProfileEnvVar, "Sandbox")
+			path := writeConfig(t, name, content)
+
+			config, err := provider.LoadConfig(provider.ConfigOptions{File: path})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if config.Profile != "sandbox" || config.Environment != environment.SandboxEnvironment {
+				t.Errorf("expected the sandbox profile and environment, got %s and %s", config.Profile, config.Environment)
+			}
+		})
+	}
+}
+
+func TestLoadConfigTOMLQuotedProfile(t *testing.T) {
+	clearConfigEnv(t)
+	path := writeConfig(t, "myscribae.toml", `
+[profiles."eu.west"]
+environment = "sandbox"
+api_url = "https://eu-west.sandbox.api.myscribae.com/graphql"
+`)
+
+	config, err := provider.LoadConfig(provider.ConfigOptions{File: path, Profile: "eu.west"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if apiUrl, _ := config.Setting("api_url"); apiUrl.Value != "https://eu-west.sandbox.api.myscribae.com/graphql" {
+		t.Errorf("expected the api url of the eu.west profile, got %s", apiUrl)
+	}
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	clearConfigEnv(t)
+	path := writeConfig(t, "myscribae.yaml", `
+environment: sandbox
+api_key: test_fromfile
+secret_key: test_secretfromfile
+api_token: tokenfromfile
+`)
+	t.Setenv(environment.ConfigFileEnvVar, path)
+	t.Setenv(environment.SecretKeyEnvVar, "test_secretfromenv1234")
+	t.Setenv(environment.ApiTokenEnvVar, "tokenfromenv")
+
+	apiToken := "explicit-token-abcdef"
+	config, err := provider.LoadConfig(provider.ConfigOptions{
+		Explicit: provider.ProviderConfig{ApiToken: &apiToken},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.Join([]string{
+		"environment = sandbox (file " + path + ")",
+		"api_url = " + environment.SandboxEnvironment.ApiUrl + " (default)",
+		"api_key = test_**** (file " + path + ")",
+		"secret_key = test_****1234 (env MYSCRIBAE_SECRET_KEY)",
+		"api_token = ****cdef (explicit)",
+	}, "\n")
+	if report := config.String(); report != expected {
+		t.Errorf("expected the report\n%s\ngot\n%s", expected, report)
+	}
+
+	providerConfig := config.ProviderConfig()
+	if *providerConfig.SecretKey != "test_secretfromenv1234" || *providerConfig.ApiToken != apiToken || *providerConfig.Environment != environment.Sandbox {
+		t.Errorf("unexpected provider config %+v", providerConfig)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	clearConfigEnv(t)
+
+	if _, err := provider.LoadConfig(provider.ConfigOptions{
+		File:    writeConfig(t, "myscribae.yaml", "api_key: live_key\n"),
+		Profile: "staging",
+	}); !errors.Is(err, provider.ErrUnknownProfile) {
+		t.Errorf("expected an unknown profile, got %v", err)
+	}
+
+	for name, content := range map[string]string{
+		"typo.yaml":   "api_kye: live_key\n",
+		"nested.toml": "[profiles.sandbox]\napi_key = [\"a\"]\n",
+		"twice.yaml":  "profiles:\n  sandbox: {}\n  Sandbox: {}\n",
+		"config.json": "{}",
+	} {
+		if _, err := provider.LoadConfig(provider.ConfigOptions{File: writeConfig(t, name, content)}); !errors.Is(err, provider.ErrInvalidConfigFile) {
+			t.Errorf("%s: expected an invalid config file, got %v", name, err)
+		}
+	}
+
+	t.Setenv(environment.ApiUrlEnvVar, "http://localhost:8080/graphql")
+	config, err := provider.LoadConfig(provider.ConfigOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Environment.Name != environment.Custom {
+		t.Errorf("expected an api url alone to be a custom environment, got %s", config.Environment)
+	}
+}
@@ -0,0 +1,57 @@
+package provider_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hasura/go-graphql-client"
+	"github.com/myscribae/myscribae-sdk-go/provider"
+)
+
+func TestDeleteRefusedByApi(t *testing.T) {
+	// the count is zero, but a subscription starts before the delete is sent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case bytes.Contains(body, []byte("active_subscriptions")) && bytes.Contains(body, []byte("script(id")):
+			w.Write([]byte(`{"data":{"provider_self":{"script_group":{"script":{"active_subscriptions":0}}}}}`))
+			return
+		case bytes.Contains(body, []byte("active_subscriptions")):
+			w.Write([]byte(`{"data":{"provider_self":{"script_group":{"active_subscriptions":0}}}}`))
+			return
+		}
+
+		w.Write([]byte(`{"errors":[{"message":"active subscriptions","extensions":{"code":"ACTIVE_SUBSCRIPTIONS","active_subscriptions":1}}]}`))
+	}))
+	defer server.Close()
+
+	prov := &provider.Provider{Client: graphql.NewClient(server.URL, nil)}
+	group, err := prov.ScriptGroup("my_group")
+	if err != nil {
+		t.Fatal(err)
+	}
+	script, err := prov.Script(group.AltID, "my_script")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, del := range map[string]func(context.Context) error{
+		"script":       script.Delete,
+		"script group": group.Delete,
+	} {
+		err := del(context.Background())
+
+		var activeErr *provider.ActiveSubscriptionsError
+		if !errors.As(err, &activeErr) || !errors.Is(err, provider.ErrActiveSubscriptions) {
+			t.Fatalf("%s: expected an active subscriptions error, got %v", name, err)
+		}
+		if activeErr.Resource != name || activeErr.Count != 1 {
+			t.Errorf("%s: unexpected error %+v", name, activeErr)
+		}
+	}
+}
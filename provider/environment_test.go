@@ -0,0 +1,75 @@
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/myscribae/myscribae-sdk-go/environment"
+	"github.com/myscribae/myscribae-sdk-go/provider"
+)
+
+func TestInitializeProviderEnvironment(t *testing.T) {
+	t.Setenv(environment.EnvironmentEnvVar, "sandbox")
+	t.Setenv(environment.ApiUrlEnvVar, "")
+
+	apiKey, secretKey := "test_api", "test_secret"
+	prov, err := provider.InitializeProvider(context.Background(), provider.ProviderConfig{
+		ApiKey:    &apiKey,
+		SecretKey: &secretKey,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prov.Environment() != environment.SandboxEnvironment || prov.ApiUrl != environment.SandboxEnvironment.ApiUrl {
+		t.Errorf("expected the sandbox environment, got %s", prov.Environment())
+	}
+	if _, err := prov.Sandbox(); err != nil {
+		t.Errorf("expected the sandbox helpers, got %v", err)
+	}
+
+	production := environment.Production
+	_, err = provider.InitializeProvider(context.Background(), provider.ProviderConfig{
+		ApiKey:      &apiKey,
+		SecretKey:   &secretKey,
+		Environment: &production,
+	})
+	if !errors.Is(err, environment.ErrEnvironmentMismatch) {
+		t.Errorf("expected sandbox keys to be refused in production, got %v", err)
+	}
+}
+
+func TestSandboxOnlyInSandbox(t *testing.T) {
+	prov := &provider.Provider{ApiUrl: "http://localhost:8080/graphql"}
+	if _, err := prov.Sandbox(); !errors.Is(err, provider.ErrNotSandbox) {
+		t.Errorf("expected the sandbox to be refused, got %v", err)
+	}
+}
+
+func TestInitializeProviderWithoutEnvironment(t *testing.T) {
+	t.Setenv(environment.EnvironmentEnvVar, "")
+	t.Setenv(environment.ApiUrlEnvVar, "")
+
+	apiKey, secretKey := "live_api", "live_secret"
+	_, err := provider.InitializeProvider(context.Background(), provider.ProviderConfig{
+		ApiKey:    &apiKey,
+		SecretKey: &secretKey,
+	})
+	if !errors.Is(err, provider.ErrMissingApiUrl) {
+		t.Errorf("expected production not to be picked without being named, got %v", err)
+	}
+}
+
+func TestInitializeProviderProductionUrl(t *testing.T) {
+	t.Setenv(environment.EnvironmentEnvVar, "")
+	t.Setenv(environment.ApiUrlEnvVar, environment.ProductionEnvironment.ApiUrl)
+
+	apiKey, secretKey := "test_api", "test_secret"
+	_, err := provider.InitializeProvider(context.Background(), provider.ProviderConfig{
+		ApiKey:    &apiKey,
+		SecretKey: &secretKey,
+	})
+	if !errors.Is(err, environment.ErrEnvironmentMismatch) {
+		t.Errorf("expected sandbox keys to be refused by the production url, got %v", err)
+	}
+}
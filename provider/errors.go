@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hasura/go-graphql-client"
+)
+
+var (
+	ErrActiveSubscriptions = errors.New("active subscriptions")
+)
+
+// ActiveSubscriptionsError is returned when deleting a script or script group
+// that still has active subscriptions.  It matches ErrActiveSubscriptions with
+// errors.Is.
+type ActiveSubscriptionsError struct {
+	// Resource is either (script) or (script group)
+	Resource string
+	AltID    string
+	// Count is zero when the api refused the delete without reporting it
+	Count uint
+	Err   error
+}
+
+func (e *ActiveSubscriptionsError) Error() string {
+	if e.Count == 0 {
+		return fmt.Sprintf("cannot delete %s %s. it has active subscriptions, archive it instead", e.Resource, e.AltID)
+	}
+	return fmt.Sprintf(
+		"cannot delete %s %s. it has %d active subscriptions, archive it instead",
+		e.Resource,
+		e.AltID,
+		e.Count,
+	)
+}
+
+func (e *ActiveSubscriptionsError) Is(target error) bool {
+	return target == ErrActiveSubscriptions
+}
+
+func (e *ActiveSubscriptionsError) Unwrap() error {
+	return e.Err
+}
+
+// errorCodeActiveSubscriptions is the graphql error extension code for
+// deletes the api refuses because of active subscriptions
+const errorCodeActiveSubscriptions = "ACTIVE_SUBSCRIPTIONS"
+
+// deleteError converts the api refusing a delete into an *ActiveSubscriptionsError,
+// with the count from the (active_subscriptions) extension when it is reported
+func deleteError(err error, resource, altID string) error {
+	extensions, ok := errorExtensions(err, errorCodeActiveSubscriptions)
+	if !ok {
+		return err
+	}
+
+	var count uint
+	if n, ok := extensions["active_subscriptions"].(float64); ok && n > 0 {
+		count = uint(n)
+	}
+
+	return &ActiveSubscriptionsError{Resource: resource, AltID: altID, Count: count, Err: err}
+}
+
+var (
+	ErrNotFound       = errors.New("not found")
+	ErrImmutableField = errors.New("immutable field")
+	ErrNotNullable    = errors.New("field cannot be cleared")
+)
+
+// errorCodeNotFound is the graphql error extension code for missing resources
+const errorCodeNotFound = "NOT_FOUND"
+
+// NotFoundError is returned when reading a script, script group or provider
+// that does not exist.  It matches ErrNotFound with errors.Is.
+type NotFoundError struct {
+	// Resource is one of (provider), (script), (script group), (member) or
+	// (subscription)
+	Resource string
+	AltID    string
+	Err      error
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Resource, e.AltID)
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// ImmutableFieldError is returned when a field that is only settable at
+// creation differs from the desired state.  It matches ErrImmutableField with
+// errors.Is.
+type ImmutableFieldError struct {
+	Field   string
+	Current string
+	Desired string
+}
+
+func (e *ImmutableFieldError) Error() string {
+	return fmt.Sprintf(
+		"%s cannot be changed after creation. current: %s, desired: %s",
+		e.Field,
+		e.Current,
+		e.Desired,
+	)
+}
+
+func (e *ImmutableFieldError) Is(target error) bool {
+	return target == ErrImmutableField
+}
+
+// hasErrorCode reports whether any of the graphql errors carries the extension code
+func hasErrorCode(err error, code string) bool {
+	_, ok := errorExtensions(err, code)
+	return ok
+}
+
+// errorExtensions returns the extensions of the first graphql error with the code
+func errorExtensions(err error, code string) (map[string]interface{}, bool) {
+	var gqlErrors graphql.Errors
+	if !errors.As(err, &gqlErrors) {
+		return nil, false
+	}
+
+	for _, e := range gqlErrors {
+		if c, ok := e.Extensions["code"].(string); ok && c == code {
+			return e.Extensions, true
+		}
+	}
+
+	return nil, false
+}
+
+var ErrConflict = errors.New("conflict")
+
+// errorCodeConflict is the graphql error extension code for failed IfMatch preconditions
+const errorCodeConflict = "CONFLICT"
+
+// ConflictError is returned when an update's IfMatch version no longer
+// matches the remote version.  It matches ErrConflict with errors.Is.
+type ConflictError struct {
+	// Resource is one of (provider), (script) or (script group)
+	Resource string
+	AltID    string
+	IfMatch  string
+	Err      error
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s %s was modified since version %s", e.Resource, e.AltID, e.IfMatch)
+}
+
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/myscribae/myscribae-sdk-go/gql"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+// MaxImageSize is the largest image that can be uploaded, 5 MiB
+const MaxImageSize = 5 << 20
+
+// ImageRules bound the dimensions of an uploaded image, in pixels
+type ImageRules struct {
+	MinWidth  int
+	MinHeight int
+	MaxWidth  int
+	MaxHeight int
+	// Square requires the width and height to be equal
+	Square bool
+}
+
+var (
+	LogoImageRules   = ImageRules{MinWidth: 128, MinHeight: 128, MaxWidth: 2048, MaxHeight: 2048, Square: true}
+	BannerImageRules = ImageRules{MinWidth: 1200, MinHeight: 300, MaxWidth: 4096, MaxHeight: 2048}
+)
+
+// imageFormats maps the accepted content types to the format image.DecodeConfig reports
+var imageFormats = map[string]string{
+	"image/png":  "png",
+	"image/jpeg": "jpeg",
+	"image/gif":  "gif",
+}
+
+// ValidateImage checks an image is a png, jpeg or gif matching contentType,
+// at most MaxImageSize and within the rules, returning a *ValidationError
+// with every violation
+func ValidateImage(field string, data []byte, contentType string, rules ImageRules) error {
+	var v validator
+	v.image(field, data, contentType, rules)
+	return v.err()
+}
+
+func (v *validator) image(field string, data []byte, contentType string, rules ImageRules) {
+	if len(data) > MaxImageSize {
+		v.add(field, "must be at most %d bytes", MaxImageSize)
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	format, ok := imageFormats[mediaType]
+	if err != nil || !ok {
+		v.add(field, "must be a png, jpeg or gif image. received: %s", contentType)
+		return
+	}
+
+	config, decoded, err := image.DecodeConfig(bytes.NewReader(data))
+	switch {
+	case err != nil:
+		v.add(field, "is not a readable image")
+		return
+	case decoded != format:
+		v.add(field, "is a %s image, but the content type is %s", decoded, mediaType)
+		return
+	}
+
+	switch {
+	case config.Width < rules.MinWidth || config.Height < rules.MinHeight:
+		v.add(field, "must be at least %dx%d pixels", rules.MinWidth, rules.MinHeight)
+	case config.Width > rules.MaxWidth || config.Height > rules.MaxHeight:
+		v.add(field, "must be at most %dx%d pixels", rules.MaxWidth, rules.MaxHeight)
+	}
+	if rules.Square && config.Width != config.Height {
+		v.add(field, "must be square")
+	}
+}
+
+// UploadLogo validates and uploads a logo, then sets it as the logo_url of
+// the provider profile.  It returns the hosted url.
+func (p *Provider) UploadLogo(ctx context.Context, image io.Reader, contentType string) (string, error) {
+	url, err := p.uploadImage(ctx, "logo", image, contentType, LogoImageRules)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := p.Update(ctx, UpdateProviderProfileInput{LogoUrl: utilities.NewOptional(url)}); err != nil {
+		return "", err
+	}
+
+	return url, nil
+}
+
+// UploadBanner validates and uploads a banner, then sets it as the
+// banner_url of the provider profile.  It returns the hosted url.
+func (p *Provider) UploadBanner(ctx context.Context, image io.Reader, contentType string) (string, error) {
+	url, err := p.uploadImage(ctx, "banner", image, contentType, BannerImageRules)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := p.Update(ctx, UpdateProviderProfileInput{BannerUrl: utilities.NewOptional(url)}); err != nil {
+		return "", err
+	}
+
+	return url, nil
+}
+
+// uploadImage hosts an image of the given kind and returns its url.  Scripts
+// and script groups have no image fields, so only provider images are
+// uploaded for now.
+func (p *Provider) uploadImage(ctx context.Context, kind string, image io.Reader, contentType string, rules ImageRules) (string, error) {
+	// read one byte past the limit so oversized images are reported
+	data, err := io.ReadAll(io.LimitReader(image, MaxImageSize+1))
+	if err != nil {
+		return "", err
+	}
+
+	if err := ValidateImage(kind, data, contentType, rules); err != nil {
+		return "", err
+	}
+
+	upload := gql.Upload{
+		Filename:    kind + "." + imageFormats[mediaTypeOf(contentType)],
+		ContentType: contentType,
+		Data:        data,
+	}
+
+	var mutation gql.UploadImage
+	client := p.Client.WithRequestModifier(gql.MultipartRequest(map[string]gql.Upload{"file": upload}, p.authenticate))
+	if err := client.Mutate(ctx, &mutation, map[string]interface{}{
+		"provider_id": p.ID(),
+		"kind":        kind,
+		"file":        upload,
+	}); err != nil {
+		return "", permissionError(err, PermissionManageProfile)
+	}
+
+	return mutation.Provider.Images.Upload.Url, nil
+}
+
+// authenticate sets the provider's api key and token, since the modifier of
+// a derived client replaces the one the provider was created with
+func (p *Provider) authenticate(r *http.Request) {
+	if p.ApiKey != nil {
+		r.Header.Set("X-MyScribae-ApiKey", *p.ApiKey)
+	}
+	if p.ApiToken != nil {
+		r.Header.Set("X-MyScribae-ApiToken", *p.ApiToken)
+	}
+}
+
+func mediaTypeOf(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType
+}
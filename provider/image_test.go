@@ -0,0 +1,127 @@
+package provider_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hasura/go-graphql-client"
+	"github.com/myscribae/myscribae-sdk-go/provider"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestValidateImage(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        []byte
+		contentType string
+		rules       provider.ImageRules
+		valid       bool
+	}{
+		{"square logo", encodePNG(t, 256, 256), "image/png", provider.LogoImageRules, true},
+		{"logo not square", encodePNG(t, 256, 128), "image/png", provider.LogoImageRules, false},
+		{"logo too small", encodePNG(t, 64, 64), "image/png", provider.LogoImageRules, false},
+		{"banner", encodePNG(t, 1500, 400), "image/png; charset=binary", provider.BannerImageRules, true},
+		{"wrong content type", encodePNG(t, 256, 256), "image/jpeg", provider.LogoImageRules, false},
+		{"unsupported type", encodePNG(t, 256, 256), "image/svg+xml", provider.LogoImageRules, false},
+		{"not an image", []byte("hello"), "image/png", provider.LogoImageRules, false},
+		{"too large", make([]byte, provider.MaxImageSize+1), "image/png", provider.LogoImageRules, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fields := violatedFields(t, provider.ValidateImage("logo", test.data, test.contentType, test.rules))
+			if test.valid && fields != nil {
+				t.Errorf("expected a valid image, got violations on %v", fields)
+			}
+			if !test.valid && !reflect.DeepEqual(fields, []string{"logo"}) {
+				t.Errorf("expected a violation on logo, got %v", fields)
+			}
+		})
+	}
+}
+
+func TestUploadLogo(t *testing.T) {
+	logo := encodePNG(t, 128, 128)
+	apiKey, apiToken := "api-key", "api-token"
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("X-MyScribae-ApiKey") != apiKey || r.Header.Get("X-MyScribae-ApiToken") != apiToken {
+			t.Errorf("expected the api key and token headers, got %v", r.Header)
+		}
+
+		if requests == 2 {
+			// the profile update sets the hosted url
+			body, _ := io.ReadAll(r.Body)
+			if !bytes.Contains(body, []byte(`logo_url\":\"https://cdn.myscribae.com/logo.png`)) {
+				t.Errorf("expected the update to set the logo url, got %s", body)
+			}
+			w.Write([]byte(`{"data":{"provider":{"edit":{"uuid":"00000000-0000-0000-0000-000000000000"}}}}`))
+			return
+		}
+
+		if err := r.ParseMultipartForm(provider.MaxImageSize); err != nil {
+			t.Errorf("expected a multipart request: %v", err)
+			return
+		}
+
+		var operations struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.Unmarshal([]byte(r.FormValue("operations")), &operations); err != nil {
+			t.Error(err)
+			return
+		}
+		if file, ok := operations.Variables["file"]; !ok || file != nil {
+			t.Errorf("expected a null file variable, got %v", operations.Variables)
+		}
+		if fileMap := r.FormValue("map"); fileMap != `{"0":["variables.file"]}` {
+			t.Errorf("unexpected map %s", fileMap)
+		}
+
+		file, header, err := r.FormFile("0")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		if !bytes.Equal(data, logo) || header.Header.Get("Content-Type") != "image/png" {
+			t.Errorf("unexpected file part %s", header.Header)
+		}
+
+		w.Write([]byte(`{"data":{"provider":{"images":{"upload":{"url":"https://cdn.myscribae.com/logo.png"}}}}}`))
+	}))
+	defer server.Close()
+
+	prov := &provider.Provider{ApiKey: &apiKey, ApiToken: &apiToken, Client: graphql.NewClient(server.URL, nil)}
+	prov.Client = prov.Client.WithRequestModifier(func(r *http.Request) {
+		r.Header.Set("X-MyScribae-ApiKey", apiKey)
+		r.Header.Set("X-MyScribae-ApiToken", apiToken)
+	})
+
+	url, err := prov.UploadLogo(context.Background(), bytes.NewReader(logo), "image/png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if url != "https://cdn.myscribae.com/logo.png" || requests != 2 {
+		t.Errorf("expected the hosted url after 2 requests, got %s after %d", url, requests)
+	}
+}
@@ -0,0 +1,30 @@
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/myscribae/myscribae-sdk-go/provider"
+)
+
+func TestMoveToWithoutProvider(t *testing.T) {
+	prov := &provider.Provider{}
+	group, err := prov.ScriptGroup("my_group")
+	if err != nil {
+		t.Fatal(err)
+	}
+	script, err := prov.Script(group.AltID, "my_script")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, target := range map[string]*provider.ScriptGroup{
+		"nil target":   nil,
+		"nil provider": {},
+	} {
+		if _, err := script.MoveTo(context.Background(), target); !errors.Is(err, provider.ErrDifferentProvider) {
+			t.Errorf("%s: expected ErrDifferentProvider, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/myscribae/myscribae-sdk-go/gql"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+// MinPriceChangeNotice is how far ahead a price change must be scheduled when
+// the script has active subscriptions, so subscribers are notified in advance
+const MinPriceChangeNotice = 14 * 24 * time.Hour
+
+var (
+	ErrEffectiveDateInPast = errors.New("price change effective date is in the past")
+	ErrInsufficientNotice  = errors.New("price change does not give subscribers enough notice")
+)
+
+// SchedulePriceChangeInput is a future price of a script
+type SchedulePriceChangeInput struct {
+	PriceInCents utilities.MoneyValue
+	EffectiveAt  time.Time
+}
+
+// SchedulePriceChange schedules the script's price to change at a future
+// date, and subscribers are notified of it.  When the script has active
+// subscriptions the effective date must be at least MinPriceChangeNotice away.
+func (s *Script) SchedulePriceChange(ctx context.Context, input SchedulePriceChangeInput) (*gql.ScheduledPriceChange, error) {
+	now := time.Now()
+	if !input.EffectiveAt.After(now) {
+		return nil, ErrEffectiveDateInPast
+	}
+
+	if input.EffectiveAt.Before(now.Add(MinPriceChangeNotice)) {
+		count, err := s.ActiveSubscriptions(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if count > 0 {
+			return nil, fmt.Errorf(
+				"%w. %d active subscriptions require the change to be effective after %s",
+				ErrInsufficientNotice,
+				count,
+				now.Add(MinPriceChangeNotice).Format(time.RFC3339),
+			)
+		}
+	}
+
+	var mutation gql.SchedulePriceChange
+	err := s.Provider.Client.Mutate(ctx, &mutation, map[string]interface{}{
+		"provider_id":     s.Provider.ID(),
+		"script_group_id": s.ScriptGroupID,
+		"id":              s.AltID,
+		"price_in_cents":  input.PriceInCents,
+		"effective_at":    utilities.NewDateTime(input.EffectiveAt),
+	})
+	if err != nil {
+		return nil, permissionError(err, PermissionManageScripts)
+	}
+
+	return &mutation.Provider.ScriptGroup.Script.PriceChanges.Schedule, nil
+}
+
+// ScheduledPriceChanges lists the script's pending price changes
+func (s *Script) ScheduledPriceChanges(ctx context.Context) ([]gql.ScheduledPriceChange, error) {
+	var query gql.GetScheduledPriceChanges
+	if err := s.Provider.Client.Query(ctx, &query, map[string]interface{}{
+		"provider_id":     s.Provider.ID(),
+		"script_group_id": s.ScriptGroupID,
+		"id":              s.AltID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return query.ProviderSelf.ScriptGroup.Script.ScheduledPriceChanges, nil
+}
+
+// CancelPriceChange cancels a pending price change
+func (s *Script) CancelPriceChange(ctx context.Context, priceChangeID uuid.UUID) error {
+	var mutation gql.CancelScheduledPriceChange
+	err := s.Provider.Client.Mutate(ctx, &mutation, map[string]interface{}{
+		"provider_id":     s.Provider.ID(),
+		"script_group_id": s.ScriptGroupID,
+		"id":              s.AltID,
+		"price_change_id": utilities.AltUuidFromUUID(priceChangeID),
+	})
+	return permissionError(err, PermissionManageScripts)
+}
+
+// PriceHistory lists every price the script has had, oldest first
+func (s *Script) PriceHistory(ctx context.Context) ([]gql.PriceHistoryEntry, error) {
+	var query gql.GetPriceHistory
+	if err := s.Provider.Client.Query(ctx, &query, map[string]interface{}{
+		"provider_id":     s.Provider.ID(),
+		"script_group_id": s.ScriptGroupID,
+		"id":              s.AltID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return query.ProviderSelf.ScriptGroup.Script.PriceHistory, nil
+}
@@ -0,0 +1,101 @@
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/myscribae/myscribae-sdk-go/provider"
+	"github.com/myscribae/myscribae-sdk-go/test_utils"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func TestScheduledPriceChanges(t *testing.T) {
+	test_utils.SetupTest(t, func(ctx context.Context, prov *provider.Provider) error {
+		sg, err := prov.ScriptGroup("test_price_schedule")
+		if err != nil {
+			return err
+		}
+
+		if _, err := sg.Create(ctx, provider.CreateScriptGroupInput{
+			Name:        "Test Price Schedule Script Group",
+			Description: "This is a test script group for scheduled prices",
+			Public:      true,
+		}); err != nil {
+			return err
+		}
+
+		script, err := prov.Script(sg.AltID, "scheduled")
+		if err != nil {
+			return err
+		}
+
+		if _, err := script.Create(ctx, provider.CreateScriptInput{
+			AltID:         "scheduled",
+			Name:          "Test Scheduled Script",
+			Description:   "This is a test script for scheduled prices",
+			Recurrence:    utilities.Monthly,
+			PriceInCents:  100,
+			Sla:           100 * time.Second,
+			TokenLifetime: 100 * time.Second,
+			Public:        true,
+		}); err != nil {
+			return err
+		}
+
+		// a past effective date is rejected
+		if _, err := script.SchedulePriceChange(ctx, provider.SchedulePriceChangeInput{
+			PriceInCents: 200,
+			EffectiveAt:  time.Now().Add(-time.Hour),
+		}); !errors.Is(err, provider.ErrEffectiveDateInPast) {
+			return fmt.Errorf("expected effective date in past error, got %v", err)
+		}
+
+		change, err := script.SchedulePriceChange(ctx, provider.SchedulePriceChangeInput{
+			PriceInCents: 200,
+			EffectiveAt:  time.Now().Add(30 * 24 * time.Hour),
+		})
+		if err != nil {
+			return err
+		}
+
+		pending, err := script.ScheduledPriceChanges(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(pending) != 1 || pending[0].Uuid != change.Uuid {
+			return fmt.Errorf("scheduled price change is not pending")
+		}
+
+		if err := script.CancelPriceChange(ctx, change.Uuid); err != nil {
+			return err
+		}
+
+		pending, err = script.ScheduledPriceChanges(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(pending) != 0 {
+			return fmt.Errorf("price change was not cancelled")
+		}
+
+		history, err := script.PriceHistory(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(history) == 0 || history[0].PriceInCents != 100 {
+			return fmt.Errorf("price history is missing the initial price")
+		}
+
+		if err := script.Delete(ctx); err != nil {
+			return err
+		}
+
+		return sg.Delete(ctx)
+	})
+}
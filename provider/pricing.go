@@ -0,0 +1,270 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"sync"
+
+	"github.com/myscribae/myscribae-sdk-go/gql"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+// DefaultPriceChangeConcurrency is how many scripts ApplyPriceChanges updates at once by default
+const DefaultPriceChangeConcurrency = 4
+
+var (
+	ErrNegativePrice       = errors.New("price adjustment results in a negative price")
+	ErrInvalidAltIDPattern = errors.New("invalid alt id pattern")
+)
+
+// Rounding is applied to an adjusted price
+type Rounding string
+
+const (
+	// RoundToCents rounds to the nearest whole cent
+	RoundToCents Rounding = "cents"
+	// RoundToNinetyNine rounds to the nearest price ending in .99
+	RoundToNinetyNine Rounding = "ninety_nine"
+)
+
+// PriceAdjustment changes a price by a fixed amount or by a percentage
+type PriceAdjustment struct {
+	// AmountCents is added to the price, and may be negative
+	AmountCents int64
+	// BasisPoints raises the price by hundredths of a percent, for example
+	// 1000 for +10% or -500 for -5%
+	BasisPoints int64
+	// Rounding defaults to RoundToCents
+	Rounding Rounding
+}
+
+// AbsoluteAdjustment changes prices by a fixed number of cents
+func AbsoluteAdjustment(cents int64, rounding Rounding) PriceAdjustment {
+	return PriceAdjustment{AmountCents: cents, Rounding: rounding}
+}
+
+// PercentageAdjustment changes prices by a whole percentage.  Percentages
+// too large for basis points saturate, so Apply reports the overflow.
+func PercentageAdjustment(percent int64, rounding Rounding) PriceAdjustment {
+	bp := percent * 100
+	switch {
+	case percent > math.MaxInt64/100:
+		bp = math.MaxInt64
+	case percent < math.MinInt64/100:
+		bp = math.MinInt64
+	}
+
+	return BasisPointsAdjustment(bp, rounding)
+}
+
+// BasisPointsAdjustment changes prices by hundredths of a percent
+func BasisPointsAdjustment(bp int64, rounding Rounding) PriceAdjustment {
+	return PriceAdjustment{BasisPoints: bp, Rounding: rounding}
+}
+
+// Apply returns the adjusted price.  The percentage is rounded half away
+// from zero to whole cents before the amount is added, and results too large
+// for a price return utilities.ErrMoneyOverflow.
+func (a PriceAdjustment) Apply(price utilities.MoneyValue) (utilities.MoneyValue, error) {
+	if price.CentsValue() > math.MaxInt64 {
+		return 0, utilities.ErrMoneyOverflow
+	}
+
+	amount := price.Money()
+	change, err := amount.BasisPoints(a.BasisPoints)
+	if err != nil {
+		return 0, err
+	}
+	if amount, err = amount.Add(change); err != nil {
+		return 0, err
+	}
+	if amount, err = amount.Add(utilities.Money{Amount: a.AmountCents, Currency: utilities.USD}); err != nil {
+		return 0, err
+	}
+
+	cents := amount.Amount
+	if cents < 0 {
+		return 0, ErrNegativePrice
+	}
+
+	switch a.Rounding {
+	case RoundToNinetyNine:
+		if cents > math.MaxInt64-100 {
+			return 0, utilities.ErrMoneyOverflow
+		}
+
+		// the candidates are the .99 endings either side of the price
+		below := (cents+1)/100*100 - 1
+		above := below + 100
+		amount.Amount = above
+		// prices under 99 cents round up to the first .99 ending
+		if cents-below < above-cents && below >= 0 {
+			amount.Amount = below
+		}
+	case RoundToCents, "":
+	default:
+		return 0, fmt.Errorf("invalid rounding %s", a.Rounding)
+	}
+
+	return amount.MoneyValue()
+}
+
+// PriceSelection selects the scripts a bulk price change applies to.  Unset
+// criteria match every script.
+type PriceSelection struct {
+	ScriptGroups []utilities.AltUuid
+	Recurrence   *utilities.Recurrence
+	// AltIDPattern is a glob matched against script alt ids, for example (pro_*)
+	AltIDPattern string
+}
+
+func (s *PriceSelection) matches(script gql.ScriptProfile) (bool, error) {
+	if len(s.ScriptGroups) > 0 {
+		found := false
+		for _, group := range s.ScriptGroups {
+			if group.String() == script.ScriptGroupAltID || group.String() == script.ScriptGroupUuid.String() {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false, nil
+		}
+	}
+
+	if s.AltIDPattern != "" {
+		match, err := path.Match(s.AltIDPattern, script.AltID)
+		if err != nil {
+			return false, fmt.Errorf("%w: %s", ErrInvalidAltIDPattern, err)
+		}
+
+		return match, nil
+	}
+
+	return true, nil
+}
+
+// PriceChange is the old and new price of a script
+type PriceChange struct {
+	Script gql.ScriptProfile
+	Old    utilities.MoneyValue
+	New    utilities.MoneyValue
+}
+
+func (c PriceChange) String() string {
+	return fmt.Sprintf("%s/%s: %s -> %s", c.Script.ScriptGroupAltID, c.Script.AltID, c.Old, c.New)
+}
+
+// PriceChangeResult is the outcome of applying a single price change
+type PriceChangeResult struct {
+	PriceChange
+	Err error
+}
+
+// PreviewPriceChanges computes the new price of every selected script without
+// changing anything.  Scripts whose price would not change are left out.
+func (p *Provider) PreviewPriceChanges(
+	ctx context.Context,
+	selection PriceSelection,
+	adjustment PriceAdjustment,
+) ([]PriceChange, error) {
+	var changes []PriceChange
+
+	it := p.IterateAllScripts(ListScriptsOptions{Recurrence: selection.Recurrence})
+	for it.Next(ctx) {
+		script := *it.Script()
+
+		match, err := selection.matches(script)
+		if err != nil {
+			return nil, err
+		}
+		if !match {
+			continue
+		}
+
+		old := utilities.NewCentValue(script.PriceInCents)
+		adjusted, err := adjustment.Apply(old)
+		if err != nil {
+			return nil, fmt.Errorf("%s/%s: %w", script.ScriptGroupAltID, script.AltID, err)
+		}
+
+		if adjusted == old {
+			continue
+		}
+
+		changes = append(changes, PriceChange{
+			Script: script,
+			Old:    old,
+			New:    adjusted,
+		})
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// ApplyPriceChanges updates the price of every script concurrently, at most
+// concurrency at a time.  Each update is conditional on the version seen by
+// the preview, so scripts changed in the meantime fail with a *ConflictError
+// instead of being overwritten.  The results are in the order of changes.
+func (p *Provider) ApplyPriceChanges(
+	ctx context.Context,
+	changes []PriceChange,
+	concurrency int,
+) []PriceChangeResult {
+	if concurrency <= 0 {
+		concurrency = DefaultPriceChangeConcurrency
+	}
+
+	results := make([]PriceChangeResult, len(changes))
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, change := range changes {
+		wg.Add(1)
+		go func(i int, change PriceChange) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = PriceChangeResult{
+				PriceChange: change,
+				Err:         p.applyPriceChange(ctx, change),
+			}
+		}(i, change)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (p *Provider) applyPriceChange(ctx context.Context, change PriceChange) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	groupID, err := utilities.NewAltUuid(change.Script.ScriptGroupAltID)
+	if err != nil {
+		return err
+	}
+
+	script, err := p.Script(groupID, change.Script.AltID)
+	if err != nil {
+		return err
+	}
+
+	version := change.Script.Version
+	_, err = script.Update(ctx, UpdateScriptInput{
+		PriceInCents: utilities.NewOptional(change.New),
+		IfMatch:      &version,
+	})
+
+	return err
+}
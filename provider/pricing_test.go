@@ -0,0 +1,80 @@
+package provider_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/myscribae/myscribae-sdk-go/provider"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func TestPriceAdjustmentApply(t *testing.T) {
+	tests := []struct {
+		name       string
+		adjustment provider.PriceAdjustment
+		price      uint
+		expected   uint
+	}{
+		{"absolute increase", provider.AbsoluteAdjustment(150, provider.RoundToCents), 499, 649},
+		{"absolute decrease", provider.AbsoluteAdjustment(-100, provider.RoundToCents), 499, 399},
+		{"percentage rounds to cents", provider.PercentageAdjustment(10, provider.RoundToCents), 499, 549},
+		{"percentage rounds to the nearest .99", provider.PercentageAdjustment(10, provider.RoundToNinetyNine), 899, 999},
+		{"percentage rounds to the next .99", provider.PercentageAdjustment(5, provider.RoundToNinetyNine), 1000, 1099},
+		{"small prices round to .99", provider.AbsoluteAdjustment(1, provider.RoundToNinetyNine), 0, 99},
+		{"basis points round half away from zero", provider.BasisPointsAdjustment(50, provider.RoundToCents), 100, 101},
+		{"percentages are exact", provider.PercentageAdjustment(7, provider.RoundToCents), 1100, 1177},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.adjustment.Apply(utilities.NewCentValue(test.price))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.CentsValue() != test.expected {
+				t.Errorf("expected %d cents, got %d", test.expected, result.CentsValue())
+			}
+		})
+	}
+}
+
+func TestPriceAdjustmentOverflow(t *testing.T) {
+	tests := []struct {
+		name       string
+		adjustment provider.PriceAdjustment
+		price      uint
+	}{
+		{"percentage", provider.PercentageAdjustment(math.MaxInt64, provider.RoundToCents), 100},
+		{"amount", provider.AbsoluteAdjustment(math.MaxInt64, provider.RoundToCents), 100},
+		{"rounding to .99", provider.AbsoluteAdjustment(math.MaxInt64-150, provider.RoundToNinetyNine), 100},
+		{"price", provider.AbsoluteAdjustment(0, provider.RoundToCents), math.MaxUint},
+	}
+
+	for _, test := range tests {
+		if _, err := test.adjustment.Apply(utilities.NewCentValue(test.price)); !errors.Is(err, utilities.ErrMoneyOverflow) {
+			t.Errorf("%s: expected an overflow, got %v", test.name, err)
+		}
+	}
+}
+
+func TestPriceAdjustmentNegative(t *testing.T) {
+	tests := []struct {
+		name       string
+		adjustment provider.PriceAdjustment
+		price      uint
+	}{
+		{"absolute", provider.AbsoluteAdjustment(-500, provider.RoundToCents), 499},
+		{"under a dollar below zero", provider.AbsoluteAdjustment(-150, provider.RoundToNinetyNine), 100},
+		{"a dollar or more below zero", provider.AbsoluteAdjustment(-300, provider.RoundToNinetyNine), 100},
+		{"percentage", provider.PercentageAdjustment(-150, provider.RoundToCents), 1000},
+	}
+
+	for _, test := range tests {
+		_, err := test.adjustment.Apply(utilities.NewCentValue(test.price))
+		if err != provider.ErrNegativePrice {
+			t.Errorf("%s: expected negative price error, got %v", test.name, err)
+		}
+	}
+}
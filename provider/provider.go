@@ -8,11 +8,13 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"sync"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
 	"github.com/hasura/go-graphql-client"
 	"github.com/myscribae/myscribae-sdk-go/environment"
+	"github.com/myscribae/myscribae-sdk-go/general"
 	"github.com/myscribae/myscribae-sdk-go/gql"
 	"github.com/myscribae/myscribae-sdk-go/utilities"
 )
@@ -21,32 +23,40 @@ type Provider struct {
 	ApiUrl string
 
 	Uuid      uuid.UUID
-	altId     *utilities.AltUuid
 	SecretKey *string
 	ApiKey    *string
+	// ApiToken is sent with requests that cannot reuse the modifier of Client,
+	// like uploads.  Set it on providers created with a token client.
+	ApiToken *string
 
 	publicKey *string
 
 	Client *graphql.Client
+
+	// generalMu guards general, which is created on first use
+	generalMu sync.Mutex
+	general   *general.MyScribae
+	// roleMu guards role, the caller's cached role
+	roleMu      sync.Mutex
+	role        *Role
+	environment environment.Environment
 }
 
 func (p *Provider) ID() utilities.AltUuid {
-	if p.altId == nil {
-		id, err := utilities.NewAltUuid(p.Uuid.String())
-		if err != nil {
-			log.Panicf("failed to create alt id: %s", err.Error())
-		}
-		p.altId = &id
-	}
-
-	return *p.altId
+	return utilities.AltUuidFromUUID(p.Uuid)
 }
 
 type ProviderConfig struct {
 	ApiKey    *string
 	SecretKey *string
 	ApiToken  *string
-	ApiUrl    *string
+	// ApiUrl overrides the url of the environment.  When no environment is
+	// named, the production or sandbox url selects that environment and any
+	// other url is a custom environment.
+	ApiUrl *string
+	// Environment names the environment, falling back to MYSCRIBAE_ENVIRONMENT.
+	// Without an environment or an api url ErrMissingApiUrl is returned.
+	Environment *environment.Name
 }
 
 type CreateProviderProfileInput struct {
@@ -62,23 +72,28 @@ type CreateProviderProfileInput struct {
 	AccountService bool    `json:"account_service"`
 }
 
+// UpdateProviderProfileInput changes the set fields of the provider profile.
+// LogoUrl, BannerUrl, Url and Color can be cleared with utilities.NullOptional.
 type UpdateProviderProfileInput struct {
-	AltID          *string `json:"alt_id"`
-	Name           *string `json:"name"`
-	CategoryID     *string `json:"category_id"`
-	Description    *string `json:"description"`
-	LogoUrl        *string `json:"logo_url"`
-	BannerUrl      *string `json:"banner_url"`
-	Url            *string `json:"url"`
-	Color          *string `json:"color"`
-	Public         *bool   `json:"public"`
-	AccountService *bool   `json:"account_service"`
+	AltID          utilities.Optional[string] `json:"alt_id"`
+	Name           utilities.Optional[string] `json:"name"`
+	CategoryID     utilities.Optional[string] `json:"category_id"`
+	Description    utilities.Optional[string] `json:"description"`
+	LogoUrl        utilities.Optional[string] `json:"logo_url"`
+	BannerUrl      utilities.Optional[string] `json:"banner_url"`
+	Url            utilities.Optional[string] `json:"url"`
+	Color          utilities.Optional[string] `json:"color"`
+	Public         utilities.Optional[bool]   `json:"public"`
+	AccountService utilities.Optional[bool]   `json:"account_service"`
+
+	// IfMatch only applies the update when the profile is still at this version
+	IfMatch *string `json:"-"`
 }
 
 var (
 	ErrProviderAlreadyInitialized = errors.New("provider already initialized")
 	ErrProviderNotInitialized     = errors.New("provider not initialized")
-	ErrMissingApiUrl              = errors.New("missing myscribae api url")
+	ErrMissingApiUrl              = environment.ErrMissingApiUrl
 	ErrMissingApiKey              = errors.New("missing myscribae api key")
 	ErrMissingSecretKey           = errors.New("missing myscribae secret key")
 	ErrFailedToCreateClient       = errors.New("failed to create graphql client")
@@ -89,6 +104,17 @@ func CreateNewProvider(ctx context.Context, client *graphql.Client, input *Creat
 		return nil, ErrFailedToCreateClient
 	}
 
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	myscribae := general.NewMyScribae(client)
+	if input.CategoryID != nil {
+		if err := validateCategory(ctx, myscribae, *input.CategoryID); err != nil {
+			return nil, err
+		}
+	}
+
 	var mutation gql.CreateNewProvider
 	err := client.Mutate(
 		ctx,
@@ -111,8 +137,9 @@ func CreateNewProvider(ctx context.Context, client *graphql.Client, input *Creat
 
 	// Created provider, return provider
 	prov := &Provider{
-		Uuid:   mutation.Providers.Create.Uuid,
-		Client: client,
+		Uuid:    mutation.Providers.Create.Uuid,
+		Client:  client,
+		general: myscribae,
 	}
 
 	// get secret key and api key
@@ -157,14 +184,25 @@ func (p *Provider) IssueSubscriberToken(
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, permissionError(err, PermissionIssueTokens)
 	}
 
 	return &mutation.Provider.Tokens.Issue, nil
 }
 
-// Sync syncs the provider with the backend
+// Update sends the changed fields of the provider profile.  When IfMatch is
+// set and the profile was modified since, a *ConflictError is returned.
 func (p *Provider) Update(ctx context.Context, profile UpdateProviderProfileInput) (*uuid.UUID, error) {
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+
+	if category, ok := profile.CategoryID.Get(); ok {
+		if err := validateCategory(ctx, p.myscribae(), category); err != nil {
+			return nil, err
+		}
+	}
+
 	var changes []byte
 	changes, err := profile.MarshalJSON()
 	if err != nil {
@@ -174,10 +212,14 @@ func (p *Provider) Update(ctx context.Context, profile UpdateProviderProfileInpu
 	// update provider
 	var mutation gql.EditProviderProfile
 	if err := p.Client.Mutate(ctx, &mutation, map[string]interface{}{
-		"id":      p.ID(),
-		"changes": string(changes),
+		"id":       p.ID(),
+		"changes":  string(changes),
+		"if_match": profile.IfMatch,
 	}); err != nil {
-		log.Panicf("failed to update provider: %s", err.Error())
+		if profile.IfMatch != nil && hasErrorCode(err, errorCodeConflict) {
+			return nil, &ConflictError{Resource: "provider", AltID: p.ID().String(), IfMatch: *profile.IfMatch, Err: err}
+		}
+		return nil, permissionError(err, PermissionManageProfile)
 	}
 
 	return &mutation.Provider.Edit.Uuid, nil
@@ -194,6 +236,9 @@ func (p *Provider) Read(ctx context.Context) (*gql.ProviderProfile, error) {
 		},
 	)
 	if err != nil {
+		if hasErrorCode(err, errorCodeNotFound) {
+			return nil, &NotFoundError{Resource: "provider", AltID: p.ID().String(), Err: err}
+		}
 		return nil, err
 	}
 
@@ -203,7 +248,7 @@ func (p *Provider) Read(ctx context.Context) (*gql.ProviderProfile, error) {
 // / SetPublic sets the provider to public or private
 func (p *Provider) SetPublic(ctx context.Context, public bool) error {
 	_, err := p.Update(ctx, UpdateProviderProfileInput{
-		Public: &public,
+		Public: utilities.NewOptional(public),
 	})
 
 	return err
@@ -239,18 +284,29 @@ func (p *CreateProviderProfileInput) Println(a ...interface{}) {
 	log.Println(fmt.Sprintf("[%v]", p.AltID), a)
 }
 
+// InitializeProvider creates a provider from the config, reading unset
+// fields from the MYSCRIBAE_ environment variables.  Use LoadConfig to also
+// read a config file.  A bare MYSCRIBAE_API_URL of production or the sandbox
+// keeps their key checks, any other url is a custom environment without them.
 func InitializeProvider(
 	ctx context.Context,
 	config ProviderConfig,
 ) (*Provider, error) {
+	if config.Environment == nil {
+		name := environment.Name(os.Getenv(environment.EnvironmentEnvVar))
+		config.Environment = &name
+	}
+
 	if config.ApiUrl == nil {
-		apiUrlEnv, success := os.LookupEnv(environment.ApiUrlEnvVar)
-		if !success {
-			return nil, ErrMissingApiUrl
-		}
+		apiUrlEnv := os.Getenv(environment.ApiUrlEnvVar)
 		config.ApiUrl = &apiUrlEnv
 	}
 
+	env, err := environment.Resolve(*config.Environment, *config.ApiUrl)
+	if err != nil {
+		return nil, err
+	}
+
 	if config.ApiKey == nil {
 		apiKeyEnv, success := os.LookupEnv(environment.ApiKeyEnvVar)
 		if !success {
@@ -267,16 +323,29 @@ func InitializeProvider(
 		config.SecretKey = &secretKeyEnv
 	}
 
+	if config.ApiToken == nil {
+		if apiTokenEnv, ok := os.LookupEnv(environment.ApiTokenEnvVar); ok {
+			config.ApiToken = &apiTokenEnv
+		}
+	}
+
+	// refuse keys from another environment, like sandbox keys in production
+	if err := errors.Join(env.CheckKey(*config.ApiKey), env.CheckKey(*config.SecretKey)); err != nil {
+		return nil, err
+	}
+
 	// Attempt to connect to backend services
-	client := gql.CreateGraphQLClient(*config.ApiUrl, nil)
+	client := gql.CreateGraphQLClient(env.ApiUrl, nil)
 	if client == nil {
 		return nil, ErrFailedToCreateClient
 	}
 
 	return &Provider{
-		ApiKey:    config.ApiKey,
-		SecretKey: config.SecretKey,
-		ApiUrl:    *config.ApiUrl,
+		ApiKey:      config.ApiKey,
+		ApiToken:    config.ApiToken,
+		SecretKey:   config.SecretKey,
+		ApiUrl:      env.ApiUrl,
+		environment: env,
 		Client: client.WithRequestModifier(
 			func(r *http.Request) {
 				if config.ApiKey != nil {
@@ -290,6 +359,16 @@ func InitializeProvider(
 	}, nil
 }
 
+// Environment returns the environment the provider was initialized for.
+// Providers created without InitializeProvider are a custom environment on
+// their ApiUrl.
+func (p *Provider) Environment() environment.Environment {
+	if p.environment.Name == "" {
+		return environment.NewCustomEnvironment(p.ApiUrl)
+	}
+	return p.environment
+}
+
 // secretClient returns a client with the provider's secret key
 func (p *Provider) secretClient() *graphql.Client {
 	client := p.Client
@@ -334,7 +413,7 @@ func (p *Provider) ResetProviderKeys(ctx context.Context) error {
 	})
 
 	if err != nil {
-		return err
+		return permissionError(err, PermissionManageKeys)
 	}
 
 	p.ApiKey = &mutation.Provider.Keys.Reset.ApiKey
@@ -344,46 +423,21 @@ func (p *Provider) ResetProviderKeys(ctx context.Context) error {
 }
 
 func (pi *UpdateProviderProfileInput) MarshalJSON() ([]byte, error) {
-	// only marshal provided fields, ignore nil fields
+	// only marshal set fields, with an explicit null for cleared fields
 	data := make(map[string]interface{})
-	if pi.AltID != nil {
-		data["alt_id"] = *pi.AltID
-	}
-
-	if pi.Name != nil {
-		data["name"] = *pi.Name
-	}
-
-	if pi.CategoryID != nil {
-		data["category_id"] = *pi.CategoryID
-	}
-
-	if pi.Description != nil {
-		data["description"] = *pi.Description
-	}
-
-	if pi.LogoUrl != nil {
-		data["logo_url"] = *pi.LogoUrl
-	}
-
-	if pi.BannerUrl != nil {
-		data["banner_url"] = *pi.BannerUrl
-	}
-
-	if pi.Url != nil {
-		data["url"] = *pi.Url
-	}
-
-	if pi.Color != nil {
-		data["color"] = *pi.Color
-	}
-
-	if pi.Public != nil {
-		data["public"] = *pi.Public
-	}
-
-	if pi.AccountService != nil {
-		data["account_service"] = *pi.AccountService
+	if err := errors.Join(
+		setChange(data, "alt_id", pi.AltID, false),
+		setChange(data, "name", pi.Name, false),
+		setChange(data, "category_id", pi.CategoryID, false),
+		setChange(data, "description", pi.Description, false),
+		setChange(data, "logo_url", pi.LogoUrl, true),
+		setChange(data, "banner_url", pi.BannerUrl, true),
+		setChange(data, "url", pi.Url, true),
+		setChange(data, "color", pi.Color, true),
+		setChange(data, "public", pi.Public, false),
+		setChange(data, "account_service", pi.AccountService, false),
+	); err != nil {
+		return nil, err
 	}
 
 	return json.Marshal(data)
@@ -2,12 +2,15 @@ package provider_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"testing"
 
+	"github.com/myscribae/myscribae-sdk-go/gql"
 	"github.com/myscribae/myscribae-sdk-go/provider"
 	"github.com/myscribae/myscribae-sdk-go/test_utils"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
 )
 
 func TestProviderEdit(t *testing.T) {
@@ -27,7 +30,7 @@ func TestProviderEdit(t *testing.T) {
 		newName := fmt.Sprintf("%s - %s", originalName, "Test")
 
 		provUuid, err := prov.Update(ctx, provider.UpdateProviderProfileInput{
-			Name: &newName,
+			Name: utilities.NewOptional(newName),
 		})
 
 		if err != nil {
@@ -58,7 +61,7 @@ func TestProviderEdit(t *testing.T) {
 
 		// attempt to change the provider name back
 		_, err = prov.Update(ctx, provider.UpdateProviderProfileInput{
-			Name: &originalName,
+			Name: utilities.NewOptional(originalName),
 		})
 		if err != nil {
 			return fmt.Errorf("failed to update provider: %v", err)
@@ -67,3 +70,45 @@ func TestProviderEdit(t *testing.T) {
 		return nil
 	})
 }
+
+func TestProviderUpdateConflict(t *testing.T) {
+	test_utils.SetupTest(t, func(ctx context.Context, prov *provider.Provider) error {
+		provData, err := prov.Read(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read provider: %v", err)
+		}
+
+		staleVersion := provData.Version
+		originalName := provData.Name
+		newName := fmt.Sprintf("%s - %s", originalName, "Conflict")
+
+		// the first conditional update succeeds and changes the version
+		if _, err := prov.Update(ctx, provider.UpdateProviderProfileInput{
+			Name:    utilities.NewOptional(newName),
+			IfMatch: &staleVersion,
+		}); err != nil {
+			return fmt.Errorf("failed to update provider: %v", err)
+		}
+
+		// the second one still uses the old version
+		_, err = prov.Update(ctx, provider.UpdateProviderProfileInput{
+			Name:    utilities.NewOptional(originalName),
+			IfMatch: &staleVersion,
+		})
+		if !errors.Is(err, provider.ErrConflict) {
+			return fmt.Errorf("expected conflict error, got %v", err)
+		}
+
+		// read-modify-write picks up the latest version
+		_, err = prov.ReadModifyWrite(ctx, func(current *gql.ProviderProfile) (provider.UpdateProviderProfileInput, error) {
+			return provider.UpdateProviderProfileInput{
+				Name: utilities.NewOptional(originalName),
+			}, nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore provider name: %v", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/myscribae/myscribae-sdk-go/gql"
+)
+
+var ErrNotSandbox = errors.New("only available in the sandbox environment")
+
+// Sandbox simulates subscriber activity, which only the sandbox environment allows
+type Sandbox struct {
+	Provider *Provider
+}
+
+// Sandbox returns the sandbox helpers, or an error matching ErrNotSandbox
+// outside of the sandbox environment
+func (p *Provider) Sandbox() (*Sandbox, error) {
+	if env := p.Environment(); !env.IsSandbox() {
+		return nil, fmt.Errorf("%w: the provider is in the %s environment", ErrNotSandbox, env)
+	}
+
+	return &Sandbox{Provider: p}, nil
+}
+
+// Subscribe simulates a subscriber subscribing to a script, starting its first period
+func (s *Sandbox) Subscribe(ctx context.Context, script *Script, subscriberID string) (*gql.SimulatedSubscription, error) {
+	var mutation gql.SimulateSubscription
+	if err := s.Provider.Client.Mutate(ctx, &mutation, map[string]interface{}{
+		"provider_id":     s.Provider.ID(),
+		"script_group_id": script.ScriptGroupID,
+		"script_id":       script.AltID,
+		"subscriber_id":   subscriberID,
+	}); err != nil {
+		if hasErrorCode(err, errorCodeNotFound) {
+			return nil, &NotFoundError{Resource: "script", AltID: script.AltID.String(), Err: err}
+		}
+		return nil, err
+	}
+
+	return &mutation.Provider.Sandbox.Subscriptions.Simulate, nil
+}
+
+// Renew simulates the end of the current period.  A failed payment leaves
+// the subscription past due.
+func (s *Sandbox) Renew(ctx context.Context, subscriptionID uuid.UUID, paymentSucceeds bool) (*gql.SimulatedSubscription, error) {
+	var mutation gql.RenewSimulatedSubscription
+	if err := s.Provider.Client.Mutate(ctx, &mutation, map[string]interface{}{
+		"provider_id":      s.Provider.ID(),
+		"id":               subscriptionID,
+		"payment_succeeds": paymentSucceeds,
+	}); err != nil {
+		return nil, s.subscriptionError(err, subscriptionID)
+	}
+
+	return &mutation.Provider.Sandbox.Subscriptions.Renew, nil
+}
+
+// Cancel simulates the subscriber canceling the subscription
+func (s *Sandbox) Cancel(ctx context.Context, subscriptionID uuid.UUID) (*gql.SimulatedSubscription, error) {
+	var mutation gql.CancelSimulatedSubscription
+	if err := s.Provider.Client.Mutate(ctx, &mutation, map[string]interface{}{
+		"provider_id": s.Provider.ID(),
+		"id":          subscriptionID,
+	}); err != nil {
+		return nil, s.subscriptionError(err, subscriptionID)
+	}
+
+	return &mutation.Provider.Sandbox.Subscriptions.Cancel, nil
+}
+
+func (s *Sandbox) subscriptionError(err error, subscriptionID uuid.UUID) error {
+	if hasErrorCode(err, errorCodeNotFound) {
+		return &NotFoundError{Resource: "subscription", AltID: subscriptionID.String(), Err: err}
+	}
+	return err
+}
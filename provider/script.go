@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/myscribae/myscribae-sdk-go/gql"
@@ -17,26 +19,74 @@ type Script struct {
 }
 
 type CreateScriptInput struct {
-	AltID            string               `json:"alt_id"`
-	Name             string               `json:"name"`
-	Description      string               `json:"description"`
-	Recurrence       utilities.Recurrence `json:"recurrence"`
-	PriceInCents     utilities.MoneyValue `json:"price_in_cents"`
-	SlaSec           utilities.UInt       `json:"sla_sec"`
-	TokenLifetimeSec utilities.UInt       `json:"token_lifetime_sec"`
-	Public           bool                 `json:"public"`
+	AltID        string               `json:"alt_id"`
+	Name         string               `json:"name"`
+	Description  string               `json:"description"`
+	Recurrence   utilities.Recurrence `json:"recurrence"`
+	PriceInCents utilities.MoneyValue `json:"price_in_cents"`
+	// Sla and TokenLifetime are sent as whole seconds, rounded to the nearest second
+	Sla           time.Duration `json:"-"`
+	TokenLifetime time.Duration `json:"-"`
+	Public        bool          `json:"public"`
+	// Metadata tags the script, for example with the owning team
+	Metadata utilities.Metadata `json:"metadata,omitempty"`
 }
 
+// MarshalJSON encodes Sla and TokenLifetime as whole seconds, like the api
+func (si CreateScriptInput) MarshalJSON() ([]byte, error) {
+	type input CreateScriptInput
+	return json.Marshal(struct {
+		input
+		SlaSec           utilities.DurationSeconds `json:"sla_sec"`
+		TokenLifetimeSec utilities.DurationSeconds `json:"token_lifetime_sec"`
+	}{input(si), utilities.DurationSeconds(si.Sla), utilities.DurationSeconds(si.TokenLifetime)})
+}
+
+// UnmarshalJSON decodes Sla and TokenLifetime from whole seconds like (90),
+// or duration strings like ("1m30s")
+func (si *CreateScriptInput) UnmarshalJSON(data []byte) error {
+	type input CreateScriptInput
+	var decoded struct {
+		input
+		SlaSec           utilities.DurationSeconds `json:"sla_sec"`
+		TokenLifetimeSec utilities.DurationSeconds `json:"token_lifetime_sec"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*si = CreateScriptInput(decoded.input)
+	si.Sla = decoded.SlaSec.Duration()
+	si.TokenLifetime = decoded.TokenLifetimeSec.Duration()
+	return nil
+}
+
+// UpdateScriptInput changes the set fields of the script.  None of them can be cleared.
 type UpdateScriptInput struct {
-	Name             *string               `json:"name"`
-	Description      *string               `json:"description"`
-	PriceInCents     *utilities.MoneyValue `json:"price_in_cents"`
-	SlaSec           *utilities.UInt       `json:"sla_sec"`
-	TokenLifetimeSec *utilities.UInt       `json:"token_lifetime_sec"`
-	Public           *bool                 `json:"public"`
+	Name          utilities.Optional[string]               `json:"name"`
+	Description   utilities.Optional[string]               `json:"description"`
+	PriceInCents  utilities.Optional[utilities.MoneyValue] `json:"price_in_cents"`
+	Sla           utilities.Optional[time.Duration]        `json:"sla_sec"`
+	TokenLifetime utilities.Optional[time.Duration]        `json:"token_lifetime_sec"`
+	Public        utilities.Optional[bool]                 `json:"public"`
+	// Metadata replaces all of the script's tags, or removes them when null
+	Metadata utilities.Optional[utilities.Metadata] `json:"metadata"`
+
+	// IfMatch only applies the update when the script is still at this version
+	IfMatch *string `json:"-"`
 }
 
+// Create creates the script.  The input is validated first, and its alt id
+// defaults to the script's alt id.
 func (s *Script) Create(ctx context.Context, input CreateScriptInput) (*uuid.UUID, error) {
+	if input.AltID == "" {
+		input.AltID = s.AltID.String()
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
 	var mutation gql.CreateNewScript
 	err := s.Provider.Client.Mutate(ctx, &mutation, map[string]interface{}{
 		"provider_id":        s.Provider.ID(),
@@ -46,19 +96,21 @@ func (s *Script) Create(ctx context.Context, input CreateScriptInput) (*uuid.UUI
 		"description":        input.Description,
 		"recurrence":         input.Recurrence,
 		"price_in_cents":     input.PriceInCents,
-		"sla_sec":            input.SlaSec,
-		"token_lifetime_sec": input.TokenLifetimeSec,
+		"sla_sec":            utilities.DurationSeconds(input.Sla),
+		"token_lifetime_sec": utilities.DurationSeconds(input.TokenLifetime),
 		"public":             input.Public,
+		"metadata":           input.Metadata,
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, permissionError(err, PermissionManageScripts)
 	}
 
 	s.Uuid = &mutation.Provider.ScriptGroup.Scripts.Create.Uuid
 	return s.Uuid, nil
 }
 
+// Read reads the script profile.  A missing script returns a *NotFoundError
 func (s *Script) Read(ctx context.Context) (*gql.ScriptProfile, error) {
 	var query gql.GetScript
 	if err := s.Provider.Client.Query(ctx, &query, map[string]interface{}{
@@ -66,24 +118,28 @@ func (s *Script) Read(ctx context.Context) (*gql.ScriptProfile, error) {
 		"script_group_id": s.ScriptGroupID,
 		"id":              s.AltID,
 	}); err != nil {
+		if hasErrorCode(err, errorCodeNotFound) {
+			return nil, &NotFoundError{Resource: "script", AltID: s.AltID.String(), Err: err}
+		}
 		return nil, err
 	}
 
+	if query.ProviderSelf.ScriptGroup.Script.Uuid == uuid.Nil {
+		return nil, &NotFoundError{Resource: "script", AltID: s.AltID.String()}
+	}
+
 	s.Uuid = &query.ProviderSelf.ScriptGroup.Script.Uuid
-	return &gql.ScriptProfile{
-		Uuid:             query.ProviderSelf.ScriptGroup.Script.Uuid,
-		AltID:            query.ProviderSelf.ScriptGroup.Script.AltID,
-		Name:             query.ProviderSelf.ScriptGroup.Script.Name,
-		Description:      query.ProviderSelf.ScriptGroup.Script.Description,
-		Recurrence:       query.ProviderSelf.ScriptGroup.Script.Recurrence,
-		PriceInCents:     query.ProviderSelf.ScriptGroup.Script.PriceInCents,
-		SlaSec:           uint(query.ProviderSelf.ScriptGroup.Script.SlaSec),
-		TokenLifetimeSec: uint(query.ProviderSelf.ScriptGroup.Script.TokenLifetimeSec),
-		Public:           query.ProviderSelf.ScriptGroup.Script.Public,
-	}, nil
+	profile := query.ProviderSelf.ScriptGroup.Script.ScriptProfile()
+	return &profile, nil
 }
 
+// Update sends the changed fields of the script.  When IfMatch is set and the
+// script was modified since, a *ConflictError is returned.
 func (s *Script) Update(ctx context.Context, input UpdateScriptInput) (*uuid.UUID, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
 	changes, err := input.MarshalJSON()
 	if err != nil {
 		return nil, err
@@ -95,66 +151,150 @@ func (s *Script) Update(ctx context.Context, input UpdateScriptInput) (*uuid.UUI
 		"script_group_id": s.ScriptGroupID,
 		"id":              s.AltID,
 		"changes":         string(changes),
+		"if_match":        input.IfMatch,
 	})
 	if err != nil {
-		return nil, err
+		if input.IfMatch != nil && hasErrorCode(err, errorCodeConflict) {
+			return nil, &ConflictError{Resource: "script", AltID: s.AltID.String(), IfMatch: *input.IfMatch, Err: err}
+		}
+		return nil, permissionError(err, PermissionManageScripts)
 	}
 
 	s.Uuid = &mutation.Provider.ScriptGroup.Script.Edit.Uuid
 	return s.Uuid, nil
 }
 
-func (s *Script) Delete(ctx context.Context) error {
-	var changes = struct {
-		Public bool `json:"public"`
-	}{
-		Public: false,
+var ErrDifferentProvider = errors.New("script group belongs to a different provider")
+
+// MoveTo moves the script into another script group of the same provider.
+// The script keeps its uuid, subscriptions and pricing.  A target without a
+// provider returns ErrDifferentProvider.
+func (s *Script) MoveTo(ctx context.Context, target *ScriptGroup) (*uuid.UUID, error) {
+	if target == nil || target.Provider == nil {
+		return nil, ErrDifferentProvider
+	}
+	if target.Provider != s.Provider && target.Provider.Uuid != s.Provider.Uuid {
+		return nil, ErrDifferentProvider
+	}
+
+	if target.AltID == s.ScriptGroupID {
+		return s.Uuid, nil
+	}
+
+	// reading through the script's provider ensures the group belongs to it
+	group := &ScriptGroup{AltID: target.AltID, Provider: s.Provider}
+	if _, err := group.Read(ctx); err != nil {
+		return nil, err
 	}
-	changesBytes, err := json.Marshal(changes)
+
+	var mutation gql.MoveScript
+	err := s.Provider.Client.Mutate(ctx, &mutation, map[string]interface{}{
+		"provider_id":            s.Provider.ID(),
+		"script_group_id":        s.ScriptGroupID,
+		"id":                     s.AltID,
+		"target_script_group_id": target.AltID,
+	})
 	if err != nil {
-		return err
+		return nil, permissionError(err, PermissionManageScripts)
 	}
 
-	var mutation gql.EditScript
-	err = s.Provider.Client.Mutate(ctx, &mutation, map[string]interface{}{
+	s.ScriptGroupID = target.AltID
+	s.Uuid = &mutation.Provider.ScriptGroup.Script.Move.Uuid
+	return s.Uuid, nil
+}
+
+// Archive hides the script from new subscribers, keeping its alt id and
+// existing subscriptions
+func (s *Script) Archive(ctx context.Context) error {
+	var mutation gql.ArchiveScript
+	err := s.Provider.Client.Mutate(ctx, &mutation, map[string]interface{}{
 		"provider_id":     s.Provider.ID(),
 		"script_group_id": s.ScriptGroupID,
 		"id":              s.AltID,
-		"changes":         string(changesBytes),
 	})
 	if err != nil {
-		return err
+		return permissionError(err, PermissionManageScripts)
 	}
 
+	s.Uuid = &mutation.Provider.ScriptGroup.Script.Archive.Uuid
 	return nil
 }
 
-func (si *UpdateScriptInput) MarshalJSON() ([]byte, error) {
-	// marshal as a map, not including optional fields if they are not set
-	m := map[string]interface{}{}
-
-	if si.Name != nil {
-		m["name"] = *si.Name
+// Restore reverses Archive
+func (s *Script) Restore(ctx context.Context) error {
+	var mutation gql.RestoreScript
+	err := s.Provider.Client.Mutate(ctx, &mutation, map[string]interface{}{
+		"provider_id":     s.Provider.ID(),
+		"script_group_id": s.ScriptGroupID,
+		"id":              s.AltID,
+	})
+	if err != nil {
+		return permissionError(err, PermissionManageScripts)
 	}
 
-	if si.Description != nil {
-		m["description"] = *si.Description
+	s.Uuid = &mutation.Provider.ScriptGroup.Script.Restore.Uuid
+	return nil
+}
+
+// ActiveSubscriptions counts the script's active subscriptions
+func (s *Script) ActiveSubscriptions(ctx context.Context) (uint, error) {
+	var query gql.GetScriptActiveSubscriptions
+	if err := s.Provider.Client.Query(ctx, &query, map[string]interface{}{
+		"provider_id":     s.Provider.ID(),
+		"script_group_id": s.ScriptGroupID,
+		"id":              s.AltID,
+	}); err != nil {
+		return 0, err
 	}
 
-	if si.PriceInCents != nil {
-		m["price_in_cents"] = *si.PriceInCents
+	return query.ProviderSelf.ScriptGroup.Script.ActiveSubscriptions, nil
+}
+
+// Delete permanently removes the script, freeing its alt id.  Scripts with
+// active subscriptions are not deleted and an *ActiveSubscriptionsError is
+// returned, use Archive to retire them instead.  The count is checked first,
+// and a subscription started since is still refused by the api.
+func (s *Script) Delete(ctx context.Context) error {
+	count, err := s.ActiveSubscriptions(ctx)
+	if err != nil {
+		return err
 	}
 
-	if si.SlaSec != nil {
-		m["sla_sec"] = *si.SlaSec
+	if count > 0 {
+		return &ActiveSubscriptionsError{
+			Resource: "script",
+			AltID:    s.AltID.String(),
+			Count:    count,
+		}
 	}
 
-	if si.TokenLifetimeSec != nil {
-		m["token_lifetime_sec"] = *si.TokenLifetimeSec
+	var mutation gql.DeleteScript
+	err = s.Provider.Client.Mutate(ctx, &mutation, map[string]interface{}{
+		"provider_id":     s.Provider.ID(),
+		"script_group_id": s.ScriptGroupID,
+		"id":              s.AltID,
+	})
+	if err != nil {
+		return permissionError(deleteError(err, "script", s.AltID.String()), PermissionManageScripts)
 	}
 
-	if si.Public != nil {
-		m["public"] = *si.Public
+	s.Uuid = nil
+	return nil
+}
+
+func (si *UpdateScriptInput) MarshalJSON() ([]byte, error) {
+	// marshal as a map, not including optional fields if they are not set
+	m := map[string]interface{}{}
+	if err := errors.Join(
+		setChange(m, "name", si.Name, false),
+		setChange(m, "description", si.Description, false),
+		setChange(m, "price_in_cents", si.PriceInCents, false),
+		setChange(m, "sla_sec", durationSeconds(si.Sla), false),
+		setChange(m, "token_lifetime_sec", durationSeconds(si.TokenLifetime), false),
+		setChange(m, "public", si.Public, false),
+		setChange(m, "metadata", si.Metadata, true),
+	); err != nil {
+		return nil, err
 	}
 
 	return json.Marshal(m)
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/myscribae/myscribae-sdk-go/gql"
@@ -19,16 +20,31 @@ type CreateScriptGroupInput struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Public      bool   `json:"public"`
+	// Metadata tags the script group, for example with the owning team
+	Metadata utilities.Metadata `json:"metadata,omitempty"`
 }
 
+// UpdateScriptGroupInput changes the set fields of the script group.  None of
+// them can be cleared.
 type UpdateScriptGroupInput struct {
-	AltID       *string `json:"alt_id"`
-	Name        *string `json:"name"`
-	Description *string `json:"description"`
-	Public      *bool   `json:"public"`
+	AltID       utilities.Optional[string] `json:"alt_id"`
+	Name        utilities.Optional[string] `json:"name"`
+	Description utilities.Optional[string] `json:"description"`
+	Public      utilities.Optional[bool]   `json:"public"`
+	// Metadata replaces all of the script group's tags, or removes them when null
+	Metadata utilities.Optional[utilities.Metadata] `json:"metadata"`
+
+	// IfMatch only applies the update when the script group is still at this version
+	IfMatch *string `json:"-"`
 }
 
+// Update sends the changed fields of the script group.  When IfMatch is set
+// and the script group was modified since, a *ConflictError is returned.
 func (sg *ScriptGroup) Update(ctx context.Context, profile UpdateScriptGroupInput) (*uuid.UUID, error) {
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+
 	var mutation gql.EditScriptGroup
 	changes, err := profile.MarshalJSON()
 	if err != nil {
@@ -39,29 +55,45 @@ func (sg *ScriptGroup) Update(ctx context.Context, profile UpdateScriptGroupInpu
 		"provider_id": sg.Provider.ID(),
 		"id":          sg.AltID,
 		"changes":     string(changes),
+		"if_match":    profile.IfMatch,
 	})
 	if err != nil {
-		panic("failed to update script group: " + err.Error())
+		if profile.IfMatch != nil && hasErrorCode(err, errorCodeConflict) {
+			return nil, &ConflictError{Resource: "script group", AltID: sg.AltID.String(), IfMatch: *profile.IfMatch, Err: err}
+		}
+		return nil, permissionError(err, PermissionManageScripts)
 	}
 
 	sg.Uuid = &mutation.Provider.ScriptGroup.Edit.Uuid
 	return sg.Uuid, nil
 }
 
+// Read reads the script group profile.  A missing script group returns a *NotFoundError
 func (sg *ScriptGroup) Read(ctx context.Context) (*gql.ScriptGroupProfile, error) {
 	var query gql.GetScriptGroup
 	if err := sg.Provider.Client.Query(ctx, &query, map[string]interface{}{
 		"id":          sg.AltID,
 		"provider_id": sg.Provider.ID(),
 	}); err != nil {
+		if hasErrorCode(err, errorCodeNotFound) {
+			return nil, &NotFoundError{Resource: "script group", AltID: sg.AltID.String(), Err: err}
+		}
 		return nil, err
 	}
 
+	if query.ProviderSelf.ScriptGroup.Uuid == uuid.Nil {
+		return nil, &NotFoundError{Resource: "script group", AltID: sg.AltID.String()}
+	}
+
 	sg.Uuid = &query.ProviderSelf.ScriptGroup.Uuid
 	return &query.ProviderSelf.ScriptGroup, nil
 }
 
 func (sg *ScriptGroup) Create(ctx context.Context, profile CreateScriptGroupInput) (*uuid.UUID, error) {
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+
 	var mutation gql.CreateNewScriptGroup
 	err := sg.Provider.Client.Mutate(ctx, &mutation, map[string]interface{}{
 		"provider_id": sg.Provider.ID(),
@@ -69,10 +101,11 @@ func (sg *ScriptGroup) Create(ctx context.Context, profile CreateScriptGroupInpu
 		"name":        profile.Name,
 		"description": profile.Description,
 		"public":      profile.Public,
+		"metadata":    profile.Metadata,
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, permissionError(err, PermissionManageScripts)
 	}
 
 	sg.Uuid = &mutation.Provider.ScriptGroups.Create.Uuid
@@ -80,28 +113,92 @@ func (sg *ScriptGroup) Create(ctx context.Context, profile CreateScriptGroupInpu
 	return sg.Uuid, nil
 }
 
-func (sg *ScriptGroup) Delete(ctx context.Context) error {
-	var public = false
-	_, err := sg.Update(ctx, UpdateScriptGroupInput{
-		Public: &public,
+// Archive hides the script group and its scripts from new subscribers,
+// keeping its alt id and existing subscriptions
+func (sg *ScriptGroup) Archive(ctx context.Context) error {
+	var mutation gql.ArchiveScriptGroup
+	err := sg.Provider.Client.Mutate(ctx, &mutation, map[string]interface{}{
+		"provider_id": sg.Provider.ID(),
+		"id":          sg.AltID,
 	})
-	return err
+	if err != nil {
+		return permissionError(err, PermissionManageScripts)
+	}
+
+	sg.Uuid = &mutation.Provider.ScriptGroup.Archive.Uuid
+	return nil
 }
 
-func (sgi *UpdateScriptGroupInput) MarshalJSON() ([]byte, error) {
-	data := make(map[string]interface{})
+// Restore reverses Archive
+func (sg *ScriptGroup) Restore(ctx context.Context) error {
+	var mutation gql.RestoreScriptGroup
+	err := sg.Provider.Client.Mutate(ctx, &mutation, map[string]interface{}{
+		"provider_id": sg.Provider.ID(),
+		"id":          sg.AltID,
+	})
+	if err != nil {
+		return permissionError(err, PermissionManageScripts)
+	}
 
-	if sgi.AltID != nil {
-		data["alt_id"] = *sgi.AltID
+	sg.Uuid = &mutation.Provider.ScriptGroup.Restore.Uuid
+	return nil
+}
+
+// ActiveSubscriptions counts the active subscriptions across the script group's scripts
+func (sg *ScriptGroup) ActiveSubscriptions(ctx context.Context) (uint, error) {
+	var query gql.GetScriptGroupActiveSubscriptions
+	if err := sg.Provider.Client.Query(ctx, &query, map[string]interface{}{
+		"provider_id": sg.Provider.ID(),
+		"id":          sg.AltID,
+	}); err != nil {
+		return 0, err
+	}
+
+	return query.ProviderSelf.ScriptGroup.ActiveSubscriptions, nil
+}
+
+// Delete permanently removes the script group and its scripts, freeing their
+// alt ids.  Groups with active subscriptions are not deleted and an
+// *ActiveSubscriptionsError is returned, use Archive to retire them instead.
+// The count is checked first, and a subscription started since is still
+// refused by the api.
+func (sg *ScriptGroup) Delete(ctx context.Context) error {
+	count, err := sg.ActiveSubscriptions(ctx)
+	if err != nil {
+		return err
 	}
-	if sgi.Name != nil {
-		data["name"] = *sgi.Name
+
+	if count > 0 {
+		return &ActiveSubscriptionsError{
+			Resource: "script group",
+			AltID:    sg.AltID.String(),
+			Count:    count,
+		}
 	}
-	if sgi.Description != nil {
-		data["description"] = *sgi.Description
+
+	var mutation gql.DeleteScriptGroup
+	err = sg.Provider.Client.Mutate(ctx, &mutation, map[string]interface{}{
+		"provider_id": sg.Provider.ID(),
+		"id":          sg.AltID,
+	})
+	if err != nil {
+		return permissionError(deleteError(err, "script group", sg.AltID.String()), PermissionManageScripts)
 	}
-	if sgi.Public != nil {
-		data["public"] = *sgi.Public
+
+	sg.Uuid = nil
+	return nil
+}
+
+func (sgi *UpdateScriptGroupInput) MarshalJSON() ([]byte, error) {
+	data := make(map[string]interface{})
+	if err := errors.Join(
+		setChange(data, "alt_id", sgi.AltID, false),
+		setChange(data, "name", sgi.Name, false),
+		setChange(data, "description", sgi.Description, false),
+		setChange(data, "public", sgi.Public, false),
+		setChange(data, "metadata", sgi.Metadata, true),
+	); err != nil {
+		return nil, err
 	}
 
 	return json.Marshal(data)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/myscribae/myscribae-sdk-go/provider"
 	"github.com/myscribae/myscribae-sdk-go/test_utils"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
 )
 
 func TestCreateAndEditScriptGroup(t *testing.T) {
@@ -54,9 +55,9 @@ func TestCreateAndEditScriptGroup(t *testing.T) {
 		)
 
 		_, err = sg.Update(ctx, provider.UpdateScriptGroupInput{
-			Name:        &newName,
-			Description: &newDescription,
-			Public:      &newPublic,
+			Name:        utilities.NewOptional(newName),
+			Description: utilities.NewOptional(newDescription),
+			Public:      utilities.NewOptional(newPublic),
 		})
 		if err != nil {
 			return err
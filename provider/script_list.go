@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/myscribae/myscribae-sdk-go/gql"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+// DefaultListLimit is the page size used when ListScriptsOptions.Limit is not set
+const DefaultListLimit = 50
+
+var (
+	ErrInvalidPriceRange = errors.New("invalid price range. min price is greater than max price")
+	ErrInvalidPagination = errors.New("invalid pagination. limit and offset must not be negative")
+)
+
+// ListScriptsOptions filters and paginates script listings
+type ListScriptsOptions struct {
+	Recurrence *utilities.Recurrence
+	Public     *bool
+	MinPrice   *utilities.MoneyValue
+	MaxPrice   *utilities.MoneyValue
+
+	Limit  int
+	Offset int
+}
+
+func (o *ListScriptsOptions) validate() error {
+	if o.Limit < 0 || o.Offset < 0 {
+		return ErrInvalidPagination
+	}
+
+	if o.MinPrice != nil && o.MaxPrice != nil && *o.MinPrice > *o.MaxPrice {
+		return ErrInvalidPriceRange
+	}
+
+	return nil
+}
+
+func (o *ListScriptsOptions) limit() int {
+	if o.Limit == 0 {
+		return DefaultListLimit
+	}
+
+	return o.Limit
+}
+
+// filterJSON only marshals the filters that are set
+func (o *ListScriptsOptions) filterJSON() ([]byte, error) {
+	data := make(map[string]interface{})
+
+	if o.Recurrence != nil {
+		data["recurrence"] = *o.Recurrence
+	}
+
+	if o.Public != nil {
+		data["public"] = *o.Public
+	}
+
+	if o.MinPrice != nil {
+		data["min_price_in_cents"] = *o.MinPrice
+	}
+
+	if o.MaxPrice != nil {
+		data["max_price_in_cents"] = *o.MaxPrice
+	}
+
+	return json.Marshal(data)
+}
+
+func (o *ListScriptsOptions) variables() (map[string]interface{}, error) {
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	filter, err := o.filterJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"filter": string(filter),
+		"limit":  o.limit(),
+		"offset": o.Offset,
+	}, nil
+}
+
+func toScriptProfiles(scripts []gql.GQLScriptProfile) []gql.ScriptProfile {
+	profiles := make([]gql.ScriptProfile, 0, len(scripts))
+	for _, s := range scripts {
+		profiles = append(profiles, s.ScriptProfile())
+	}
+
+	return profiles
+}
+
+// ListScriptGroups lists all of the provider's script groups
+func (p *Provider) ListScriptGroups(ctx context.Context) ([]gql.ScriptGroupProfile, error) {
+	var query gql.ListScriptGroups
+	if err := p.Client.Query(ctx, &query, map[string]interface{}{
+		"provider_id": p.ID(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return query.ProviderSelf.ScriptGroups, nil
+}
+
+// ListScripts lists a single page of the scripts within the script group
+func (sg *ScriptGroup) ListScripts(ctx context.Context, opts ListScriptsOptions) ([]gql.ScriptProfile, error) {
+	variables, err := opts.variables()
+	if err != nil {
+		return nil, err
+	}
+	variables["provider_id"] = sg.Provider.ID()
+	variables["script_group_id"] = sg.AltID
+
+	var query gql.ListScripts
+	if err := sg.Provider.Client.Query(ctx, &query, variables); err != nil {
+		return nil, err
+	}
+
+	return toScriptProfiles(query.ProviderSelf.ScriptGroup.Scripts), nil
+}
+
+// IterateScripts returns an iterator over every page of the script group's scripts
+func (sg *ScriptGroup) IterateScripts(opts ListScriptsOptions) *ScriptIterator {
+	return newScriptIterator(opts, sg.ListScripts)
+}
+
+// ListAllScripts lists a single page of the scripts across all of the provider's script groups
+func (p *Provider) ListAllScripts(ctx context.Context, opts ListScriptsOptions) ([]gql.ScriptProfile, error) {
+	variables, err := opts.variables()
+	if err != nil {
+		return nil, err
+	}
+	variables["provider_id"] = p.ID()
+
+	var query gql.ListAllScripts
+	if err := p.Client.Query(ctx, &query, variables); err != nil {
+		return nil, err
+	}
+
+	return toScriptProfiles(query.ProviderSelf.Scripts), nil
+}
+
+// IterateAllScripts returns an iterator over every page of the provider's scripts
+func (p *Provider) IterateAllScripts(opts ListScriptsOptions) *ScriptIterator {
+	return newScriptIterator(opts, p.ListAllScripts)
+}
+
+// ScriptIterator walks a script listing page by page
+//
+//	it := sg.IterateScripts(provider.ListScriptsOptions{})
+//	for it.Next(ctx) {
+//		script := it.Script()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ScriptIterator struct {
+	opts  ListScriptsOptions
+	fetch func(ctx context.Context, opts ListScriptsOptions) ([]gql.ScriptProfile, error)
+
+	page    []gql.ScriptProfile
+	index   int
+	current *gql.ScriptProfile
+	done    bool
+	err     error
+}
+
+func newScriptIterator(
+	opts ListScriptsOptions,
+	fetch func(ctx context.Context, opts ListScriptsOptions) ([]gql.ScriptProfile, error),
+) *ScriptIterator {
+	return &ScriptIterator{
+		opts:  opts,
+		fetch: fetch,
+	}
+}
+
+// Next advances to the next script, fetching the next page when required
+func (it *ScriptIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	if it.index >= len(it.page) {
+		if it.done {
+			return false
+		}
+
+		page, err := it.fetch(ctx, it.opts)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		// a short page is the last page
+		it.done = len(page) < it.opts.limit()
+		it.opts.Offset += len(page)
+		it.page = page
+		it.index = 0
+
+		if len(page) == 0 {
+			return false
+		}
+	}
+
+	it.current = &it.page[it.index]
+	it.index++
+	return true
+}
+
+// Script returns the script the iterator is positioned at
+func (it *ScriptIterator) Script() *gql.ScriptProfile {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *ScriptIterator) Err() error {
+	return it.err
+}
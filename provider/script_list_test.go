@@ -0,0 +1,105 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/myscribae/myscribae-sdk-go/provider"
+	"github.com/myscribae/myscribae-sdk-go/test_utils"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func TestListScripts(t *testing.T) {
+	test_utils.SetupTest(t, func(ctx context.Context, prov *provider.Provider) error {
+		sg, err := prov.ScriptGroup("test_listing")
+		if err != nil {
+			return err
+		}
+
+		if _, err := sg.Create(ctx, provider.CreateScriptGroupInput{
+			Name:        "Test Listing Script Group",
+			Description: "This is a test script group for listing",
+			Public:      true,
+		}); err != nil {
+			return err
+		}
+
+		for _, altID := range []string{"listed_cheap", "listed_expensive"} {
+			script, err := prov.Script(sg.AltID, altID)
+			if err != nil {
+				return err
+			}
+
+			price := utilities.NewCentValue(100)
+			if altID == "listed_expensive" {
+				price = utilities.NewCentValue(10000)
+			}
+
+			if _, err := script.Create(ctx, provider.CreateScriptInput{
+				AltID:         altID,
+				Name:          "Test Listed Script",
+				Description:   "This is a test script for listing",
+				Recurrence:    utilities.Monthly,
+				PriceInCents:  price,
+				Sla:           100 * time.Second,
+				TokenLifetime: 100 * time.Second,
+				Public:        true,
+			}); err != nil {
+				return err
+			}
+		}
+
+		// list every script in the group
+		scripts, err := sg.ListScripts(ctx, provider.ListScriptsOptions{})
+		if err != nil {
+			return err
+		}
+
+		if len(scripts) != 2 {
+			return fmt.Errorf("expected 2 scripts, got %d", len(scripts))
+		}
+
+		// filter by price range
+		maxPrice := utilities.NewCentValue(1000)
+		scripts, err = sg.ListScripts(ctx, provider.ListScriptsOptions{
+			MaxPrice: &maxPrice,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(scripts) != 1 || scripts[0].AltID != "listed_cheap" {
+			return fmt.Errorf("price filter returned the wrong scripts")
+		}
+
+		// iterate one script per page
+		it := prov.IterateAllScripts(provider.ListScriptsOptions{Limit: 1})
+		count := 0
+		for it.Next(ctx) {
+			if it.Script() == nil {
+				return fmt.Errorf("iterator script is nil")
+			}
+			count++
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+
+		if count < 2 {
+			return fmt.Errorf("expected at least 2 scripts, got %d", count)
+		}
+
+		// invalid price range
+		minPrice := utilities.NewCentValue(2000)
+		if _, err := sg.ListScripts(ctx, provider.ListScriptsOptions{
+			MinPrice: &minPrice,
+			MaxPrice: &maxPrice,
+		}); err != provider.ErrInvalidPriceRange {
+			return fmt.Errorf("expected invalid price range error, got %v", err)
+		}
+
+		return nil
+	})
+}
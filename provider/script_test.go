@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/myscribae/myscribae-sdk-go/provider"
 	"github.com/myscribae/myscribae-sdk-go/test_utils"
@@ -39,14 +40,14 @@ func TestScriptCreateAndEdit(t *testing.T) {
 			return err
 		}
 		createScriptOptions := provider.CreateScriptInput{
-			AltID:            "test",
-			Name:             "Test Script",
-			Description:      "This is a test script",
-			Recurrence:       "monthly",
-			PriceInCents:     100,
-			SlaSec:           utilities.NewUInt(100),
-			TokenLifetimeSec: utilities.NewUInt(100),
-			Public:           true,
+			AltID:         "test",
+			Name:          "Test Script",
+			Description:   "This is a test script",
+			Recurrence:    utilities.Monthly,
+			PriceInCents:  100,
+			Sla:           100 * time.Second,
+			TokenLifetime: 100 * time.Second,
+			Public:        true,
 		}
 
 		scriptUuid, err := script.Create(ctx, createScriptOptions)
@@ -74,21 +75,21 @@ func TestScriptCreateAndEdit(t *testing.T) {
 
 		// edit the test script
 		var (
-			newName                  = "Test Script - Edited"
-			newDescription           = "This is a test script - edited"
-			newPriceInCents          = utilities.NewCentValue(200)
-			newSlaSec           uint = 200
-			newTokenLifetimeSec uint = 200
-			newPublic                = false
+			newName          = "Test Script - Edited"
+			newDescription   = "This is a test script - edited"
+			newPriceInCents  = utilities.NewCentValue(200)
+			newSla           = 200 * time.Second
+			newTokenLifetime = 200 * time.Second
+			newPublic        = false
 		)
 
 		_, err = script.Update(ctx, provider.UpdateScriptInput{
-			Name:             &newName,
-			Description:      &newDescription,
-			PriceInCents:     &newPriceInCents,
-			SlaSec:           utilities.NewUIntPointer(&newSlaSec),
-			TokenLifetimeSec: utilities.NewUIntPointer(&newTokenLifetimeSec),
-			Public:           &newPublic,
+			Name:          utilities.NewOptional(newName),
+			Description:   utilities.NewOptional(newDescription),
+			PriceInCents:  utilities.NewOptional(newPriceInCents),
+			Sla:           utilities.NewOptional(newSla),
+			TokenLifetime: utilities.NewOptional(newTokenLifetime),
+			Public:        utilities.NewOptional(newPublic),
 		})
 
 		if err != nil {
@@ -116,11 +117,11 @@ func TestScriptCreateAndEdit(t *testing.T) {
 			return fmt.Errorf("script price in cents is incorrect")
 		}
 
-		if scriptDataNew.SlaSec != newSlaSec {
+		if scriptDataNew.Sla != newSla {
 			return fmt.Errorf("script sla_sec is incorrect")
 		}
 
-		if scriptDataNew.TokenLifetimeSec != newTokenLifetimeSec {
+		if scriptDataNew.TokenLifetime != newTokenLifetime {
 			return fmt.Errorf("script token_lifetime_sec is incorrect")
 		}
 
@@ -137,3 +138,156 @@ func TestScriptCreateAndEdit(t *testing.T) {
 		return nil
 	})
 }
+
+func TestScriptArchiveAndRestore(t *testing.T) {
+	test_utils.SetupTest(t, func(ctx context.Context, prov *provider.Provider) error {
+		sg, err := prov.ScriptGroup("test_archive")
+		if err != nil {
+			return err
+		}
+
+		if _, err := sg.Create(ctx, provider.CreateScriptGroupInput{
+			Name:        "Test Archive Script Group",
+			Description: "This is a test script group for archiving",
+			Public:      true,
+		}); err != nil {
+			return err
+		}
+
+		script, err := prov.Script(sg.AltID, "archived")
+		if err != nil {
+			return err
+		}
+
+		if _, err := script.Create(ctx, provider.CreateScriptInput{
+			AltID:         "archived",
+			Name:          "Test Archived Script",
+			Description:   "This is a test script for archiving",
+			Recurrence:    utilities.Monthly,
+			PriceInCents:  100,
+			Sla:           100 * time.Second,
+			TokenLifetime: 100 * time.Second,
+			Public:        true,
+		}); err != nil {
+			return err
+		}
+
+		// archive the test script
+		if err := script.Archive(ctx); err != nil {
+			return err
+		}
+
+		scriptData, err := script.Read(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !scriptData.Archived {
+			return fmt.Errorf("script was not archived")
+		}
+
+		if !scriptData.Public {
+			return fmt.Errorf("archiving changed the public flag")
+		}
+
+		// restore the test script
+		if err := script.Restore(ctx); err != nil {
+			return err
+		}
+
+		scriptData, err = script.Read(ctx)
+		if err != nil {
+			return err
+		}
+
+		if scriptData.Archived {
+			return fmt.Errorf("script was not restored")
+		}
+
+		// hard delete the test script and group
+		if err := script.Delete(ctx); err != nil {
+			return err
+		}
+
+		if _, err := script.Read(ctx); err == nil {
+			return fmt.Errorf("script was not deleted")
+		}
+
+		return sg.Delete(ctx)
+	})
+}
+
+func TestScriptMoveTo(t *testing.T) {
+	test_utils.SetupTest(t, func(ctx context.Context, prov *provider.Provider) error {
+		groups := make([]*provider.ScriptGroup, 0, 2)
+		for _, altID := range []string{"test_move_from", "test_move_to"} {
+			sg, err := prov.ScriptGroup(altID)
+			if err != nil {
+				return err
+			}
+
+			if _, err := sg.Create(ctx, provider.CreateScriptGroupInput{
+				Name:        "Test Move Script Group",
+				Description: "This is a test script group for moving scripts",
+				Public:      true,
+			}); err != nil {
+				return err
+			}
+
+			groups = append(groups, sg)
+		}
+
+		script, err := prov.Script(groups[0].AltID, "moved")
+		if err != nil {
+			return err
+		}
+
+		scriptUuid, err := script.Create(ctx, provider.CreateScriptInput{
+			AltID:         "moved",
+			Name:          "Test Moved Script",
+			Description:   "This is a test script for moving",
+			Recurrence:    utilities.Monthly,
+			PriceInCents:  100,
+			Sla:           100 * time.Second,
+			TokenLifetime: 100 * time.Second,
+			Public:        true,
+		})
+		if err != nil {
+			return err
+		}
+		originalUuid := *scriptUuid
+
+		if _, err := script.MoveTo(ctx, groups[1]); err != nil {
+			return err
+		}
+
+		if script.ScriptGroupID != groups[1].AltID {
+			return fmt.Errorf("script group id was not updated")
+		}
+
+		scriptData, err := script.Read(ctx)
+		if err != nil {
+			return err
+		}
+
+		if scriptData.Uuid != originalUuid {
+			return fmt.Errorf("script uuid changed when moving")
+		}
+
+		if scriptData.PriceInCents != 100 {
+			return fmt.Errorf("script price changed when moving")
+		}
+
+		if err := script.Delete(ctx); err != nil {
+			return err
+		}
+
+		for _, sg := range groups {
+			if err := sg.Delete(ctx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
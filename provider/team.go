@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/myscribae/myscribae-sdk-go/gql"
+)
+
+// Role is the role of a user on a provider, which decides what they can do
+type Role string
+
+const (
+	RoleOwner     Role = "owner"
+	RoleAdmin     Role = "admin"
+	RoleDeveloper Role = "developer"
+	RoleViewer    Role = "viewer"
+	// RoleUnknown is the role of callers the api reports no role for, like
+	// api key sessions.  Their permissions are left for the api to decide.
+	RoleUnknown Role = ""
+)
+
+// roleRanks orders the roles, each role can do everything the roles below it can
+var roleRanks = map[Role]int{
+	RoleViewer:    1,
+	RoleDeveloper: 2,
+	RoleAdmin:     3,
+	RoleOwner:     4,
+}
+
+func (r Role) Valid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Allows reports whether the role has the permission
+func (r Role) Allows(permission Permission) bool {
+	required, ok := permissionRoles[permission]
+	return ok && roleRanks[r] >= roleRanks[required]
+}
+
+// Permission is a group of provider operations that needs a minimum role
+type Permission string
+
+const (
+	// PermissionRead covers reading the profile, scripts, script groups and members
+	PermissionRead Permission = "read"
+	// PermissionManageScripts covers creating, updating, pricing, archiving
+	// and deleting scripts and script groups
+	PermissionManageScripts Permission = "manage_scripts"
+	// PermissionIssueTokens covers issuing subscriber tokens
+	PermissionIssueTokens Permission = "issue_tokens"
+	// PermissionManageProfile covers updating the profile and its images
+	PermissionManageProfile Permission = "manage_profile"
+	// PermissionManageKeys covers resetting the api and secret keys
+	PermissionManageKeys Permission = "manage_keys"
+	// PermissionManageMembers covers inviting, changing the role of and removing members
+	PermissionManageMembers Permission = "manage_members"
+)
+
+var permissionRoles = map[Permission]Role{
+	PermissionRead:          RoleViewer,
+	PermissionManageScripts: RoleDeveloper,
+	PermissionIssueTokens:   RoleDeveloper,
+	PermissionManageProfile: RoleAdmin,
+	PermissionManageKeys:    RoleAdmin,
+	PermissionManageMembers: RoleAdmin,
+}
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+// errorCodeForbidden is the graphql error extension code for operations the caller's role does not allow
+const errorCodeForbidden = "FORBIDDEN"
+
+// PermissionError is returned when the caller's role does not allow an
+// operation.  It matches ErrPermissionDenied with errors.Is.
+type PermissionError struct {
+	Permission Permission
+	// Role is the caller's role, empty when the api denied the operation
+	// before the role was known
+	Role Role
+	Err  error
+}
+
+func (e *PermissionError) Error() string {
+	if e.Role == "" {
+		return fmt.Sprintf("%s: %s is not allowed", ErrPermissionDenied, e.Permission)
+	}
+	return fmt.Sprintf("%s: %s requires the %s role, the caller is %s", ErrPermissionDenied, e.Permission, permissionRoles[e.Permission], e.Role)
+}
+
+func (e *PermissionError) Is(target error) bool {
+	return target == ErrPermissionDenied
+}
+
+func (e *PermissionError) Unwrap() error {
+	return e.Err
+}
+
+// Role returns the caller's role on the provider, read from the profile once
+// and cached.  It is RoleUnknown when the api reports no role.
+func (p *Provider) Role(ctx context.Context) (Role, error) {
+	p.roleMu.Lock()
+	cached := p.role
+	p.roleMu.Unlock()
+	if cached != nil {
+		return *cached, nil
+	}
+
+	profile, err := p.Read(ctx)
+	if err != nil {
+		return RoleUnknown, err
+	}
+
+	role := RoleUnknown
+	if profile.MyRole != nil {
+		role = Role(*profile.MyRole)
+	}
+
+	p.roleMu.Lock()
+	p.role = &role
+	p.roleMu.Unlock()
+
+	return role, nil
+}
+
+// clearRole drops the cached role, after the caller's membership may have changed
+func (p *Provider) clearRole() {
+	p.roleMu.Lock()
+	p.role = nil
+	p.roleMu.Unlock()
+}
+
+// Can reports whether the caller's role allows the permission.  An unknown
+// role is allowed, the api has the final say.
+func (p *Provider) Can(ctx context.Context, permission Permission) (bool, error) {
+	role, err := p.Role(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return role == RoleUnknown || role.Allows(permission), nil
+}
+
+// Require returns a *PermissionError when the caller's role does not allow
+// the permission, so an operation can be checked before it is attempted.  An
+// unknown role is not checked, the api refuses the operation if it must.
+func (p *Provider) Require(ctx context.Context, permission Permission) error {
+	role, err := p.Role(ctx)
+	if err != nil {
+		return err
+	}
+
+	if role != RoleUnknown && !role.Allows(permission) {
+		return &PermissionError{Permission: permission, Role: role}
+	}
+
+	return nil
+}
+
+// permissionError converts the api denying an operation into a *PermissionError
+func permissionError(err error, permission Permission) error {
+	if hasErrorCode(err, errorCodeForbidden) {
+		return &PermissionError{Permission: permission, Err: err}
+	}
+	return err
+}
+
+// Members lists the members of the provider, including pending invites
+func (p *Provider) Members(ctx context.Context) ([]gql.ProviderMember, error) {
+	var query gql.ListProviderMembers
+	if err := p.Client.Query(ctx, &query, map[string]interface{}{
+		"provider_id": p.ID(),
+	}); err != nil {
+		return nil, permissionError(err, PermissionRead)
+	}
+
+	return query.ProviderSelf.Members, nil
+}
+
+// InviteMember invites a user by email with the role.  They are listed as
+// (invited) until they accept.
+func (p *Provider) InviteMember(ctx context.Context, email string, role Role) (*gql.ProviderMember, error) {
+	var v validator
+	v.email("email", email)
+	v.role("role", role)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	if err := p.Require(ctx, PermissionManageMembers); err != nil {
+		return nil, err
+	}
+
+	var mutation gql.InviteProviderMember
+	if err := p.Client.Mutate(ctx, &mutation, map[string]interface{}{
+		"provider_id": p.ID(),
+		"email":       email,
+		"role":        string(role),
+	}); err != nil {
+		return nil, permissionError(err, PermissionManageMembers)
+	}
+
+	return &mutation.Provider.Members.Invite, nil
+}
+
+// ChangeMemberRole changes the role of a member
+func (p *Provider) ChangeMemberRole(ctx context.Context, userID uuid.UUID, role Role) (*gql.ProviderMember, error) {
+	var v validator
+	v.role("role", role)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	if err := p.Require(ctx, PermissionManageMembers); err != nil {
+		return nil, err
+	}
+
+	var mutation gql.ChangeProviderMemberRole
+	if err := p.Client.Mutate(ctx, &mutation, map[string]interface{}{
+		"provider_id": p.ID(),
+		"user_id":     userID,
+		"role":        string(role),
+	}); err != nil {
+		return nil, p.memberError(err, userID)
+	}
+
+	// the caller may have changed their own role
+	p.clearRole()
+
+	return &mutation.Provider.Members.Member.ChangeRole, nil
+}
+
+// RemoveMember removes a member, or cancels their invite
+func (p *Provider) RemoveMember(ctx context.Context, userID uuid.UUID) error {
+	if err := p.Require(ctx, PermissionManageMembers); err != nil {
+		return err
+	}
+
+	var mutation gql.RemoveProviderMember
+	if err := p.Client.Mutate(ctx, &mutation, map[string]interface{}{
+		"provider_id": p.ID(),
+		"user_id":     userID,
+	}); err != nil {
+		return p.memberError(err, userID)
+	}
+
+	p.clearRole()
+
+	return nil
+}
+
+func (p *Provider) memberError(err error, userID uuid.UUID) error {
+	if hasErrorCode(err, errorCodeNotFound) {
+		return &NotFoundError{Resource: "member", AltID: userID.String(), Err: err}
+	}
+	return permissionError(err, PermissionManageMembers)
+}
+
+func (v *validator) role(field string, role Role) {
+	if !role.Valid() {
+		v.add(field, "must be one of (owner), (admin), (developer) or (viewer)")
+	}
+}
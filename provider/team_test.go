@@ -0,0 +1,180 @@
+package provider_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hasura/go-graphql-client"
+	"github.com/myscribae/myscribae-sdk-go/provider"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func TestRoleAllows(t *testing.T) {
+	tests := []struct {
+		role       provider.Role
+		permission provider.Permission
+		allowed    bool
+	}{
+		{provider.RoleViewer, provider.PermissionRead, true},
+		{provider.RoleViewer, provider.PermissionManageScripts, false},
+		{provider.RoleDeveloper, provider.PermissionIssueTokens, true},
+		{provider.RoleDeveloper, provider.PermissionManageMembers, false},
+		{provider.RoleAdmin, provider.PermissionManageKeys, true},
+		{provider.RoleOwner, provider.PermissionManageMembers, true},
+		{provider.Role("guest"), provider.PermissionRead, false},
+		{provider.RoleOwner, provider.Permission("unknown"), false},
+	}
+
+	for _, test := range tests {
+		if allowed := test.role.Allows(test.permission); allowed != test.allowed {
+			t.Errorf("%s %s: expected %t, got %t", test.role, test.permission, test.allowed, allowed)
+		}
+	}
+}
+
+// teamServer answers the profile read with the role, null when it is empty,
+// and records the mutations sent
+func teamServer(t *testing.T, role string, mutation string, mutations *int) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if bytes.Contains(body, []byte("provider_self")) {
+			myRole := "null"
+			if role != "" {
+				myRole = `"` + role + `"`
+			}
+			w.Write([]byte(`{"data":{"provider_self":{"my_role":` + myRole + `}}}`))
+			return
+		}
+
+		*mutations++
+		w.Write([]byte(mutation))
+	}))
+}
+
+func TestInviteMember(t *testing.T) {
+	invited := `{"data":{"provider":{"members":{"invite":{"email":"new@example.com","role":"developer","status":"invited"}}}}}`
+
+	t.Run("admin invites", func(t *testing.T) {
+		var mutations int
+		server := teamServer(t, "admin", invited, &mutations)
+		defer server.Close()
+
+		prov := &provider.Provider{Client: graphql.NewClient(server.URL, nil)}
+		member, err := prov.InviteMember(context.Background(), "new@example.com", provider.RoleDeveloper)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if member.Status != "invited" || mutations != 1 {
+			t.Errorf("expected an invited member, got %+v after %d mutations", member, mutations)
+		}
+	})
+
+	t.Run("viewer is denied before sending", func(t *testing.T) {
+		var mutations int
+		server := teamServer(t, "viewer", invited, &mutations)
+		defer server.Close()
+
+		prov := &provider.Provider{Client: graphql.NewClient(server.URL, nil)}
+		_, err := prov.InviteMember(context.Background(), "new@example.com", provider.RoleDeveloper)
+
+		var permissionErr *provider.PermissionError
+		if !errors.As(err, &permissionErr) || !errors.Is(err, provider.ErrPermissionDenied) {
+			t.Fatalf("expected a permission error, got %v", err)
+		}
+		if permissionErr.Role != provider.RoleViewer || permissionErr.Permission != provider.PermissionManageMembers || mutations != 0 {
+			t.Errorf("unexpected permission error %+v after %d mutations", permissionErr, mutations)
+		}
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		prov := &provider.Provider{}
+		_, err := prov.InviteMember(context.Background(), "not an email", provider.Role("guest"))
+		if fields := violatedFields(t, err); !reflect.DeepEqual(fields, []string{"email", "role"}) {
+			t.Errorf("expected email and role violations, got %v", fields)
+		}
+	})
+}
+
+func TestInviteMemberUnknownRole(t *testing.T) {
+	invited := `{"data":{"provider":{"members":{"invite":{"email":"new@example.com","role":"viewer","status":"invited"}}}}}`
+
+	// api key sessions have no role
+	var mutations int
+	server := teamServer(t, "", invited, &mutations)
+	defer server.Close()
+
+	prov := &provider.Provider{Client: graphql.NewClient(server.URL, nil)}
+	if _, err := prov.InviteMember(context.Background(), "new@example.com", provider.RoleViewer); err != nil {
+		t.Fatalf("expected the api to decide, got %v", err)
+	}
+	if mutations != 1 {
+		t.Errorf("expected the invite to be sent, got %d mutations", mutations)
+	}
+
+	if can, err := prov.Can(context.Background(), provider.PermissionManageMembers); err != nil || !can {
+		t.Errorf("expected an unknown role to be allowed, got %t %v", can, err)
+	}
+}
+
+func TestRemoveMemberForbidden(t *testing.T) {
+	var mutations int
+	server := teamServer(t, "owner", `{"errors":[{"message":"forbidden","extensions":{"code":"FORBIDDEN"}}]}`, &mutations)
+	defer server.Close()
+
+	prov := &provider.Provider{Client: graphql.NewClient(server.URL, nil)}
+	err := prov.RemoveMember(context.Background(), uuid.New())
+	if !errors.Is(err, provider.ErrPermissionDenied) || mutations != 1 {
+		t.Errorf("expected the api to deny the removal, got %v after %d mutations", err, mutations)
+	}
+}
+
+func TestMutationsForbidden(t *testing.T) {
+	var mutations int
+	server := teamServer(t, "developer", `{"errors":[{"message":"forbidden","extensions":{"code":"FORBIDDEN"}}]}`, &mutations)
+	defer server.Close()
+
+	prov := &provider.Provider{Client: graphql.NewClient(server.URL, nil)}
+	group, err := prov.ScriptGroup("my_group")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[provider.Permission]func() error{
+		provider.PermissionManageProfile: func() error {
+			_, err := prov.Update(context.Background(), provider.UpdateProviderProfileInput{Public: utilities.NewOptional(true)})
+			return err
+		},
+		provider.PermissionManageKeys:    func() error { return prov.ResetProviderKeys(context.Background()) },
+		provider.PermissionManageScripts: func() error { return group.Archive(context.Background()) },
+	}
+
+	for permission, mutate := range tests {
+		var permissionErr *provider.PermissionError
+		if err := mutate(); !errors.As(err, &permissionErr) || permissionErr.Permission != permission {
+			t.Errorf("%s: expected a permission error, got %v", permission, err)
+		}
+	}
+
+	// the cached role is shared by concurrent operations
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := prov.Require(context.Background(), provider.PermissionManageScripts); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
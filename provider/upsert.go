@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/myscribae/myscribae-sdk-go/gql"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+// UpsertAction reports what an upsert did
+type UpsertAction string
+
+const (
+	UpsertCreated   UpsertAction = "created"
+	UpsertUpdated   UpsertAction = "updated"
+	UpsertUnchanged UpsertAction = "unchanged"
+)
+
+// ScriptChanges returns an update containing only the fields of desired that
+// differ from current.  Nil desired metadata leaves the script's metadata as
+// it is.  Recurrence cannot be updated, so a different recurrence returns an
+// *ImmutableFieldError.
+func ScriptChanges(current gql.ScriptProfile, desired CreateScriptInput) (UpdateScriptInput, error) {
+	var update UpdateScriptInput
+	currentRecurrence, err := utilities.NewRecurrence(current.Recurrence)
+	if err != nil || *currentRecurrence != desired.Recurrence {
+		return update, &ImmutableFieldError{
+			Field:   "recurrence",
+			Current: current.Recurrence,
+			Desired: desired.Recurrence.String(),
+		}
+	}
+
+	if current.Name != desired.Name {
+		update.Name = utilities.NewOptional(desired.Name)
+	}
+	if current.Description != desired.Description {
+		update.Description = utilities.NewOptional(desired.Description)
+	}
+	if current.PriceInCents != desired.PriceInCents.CentsValue() {
+		update.PriceInCents = utilities.NewOptional(desired.PriceInCents)
+	}
+	if current.Sla != utilities.RoundToSeconds(desired.Sla) {
+		update.Sla = utilities.NewOptional(desired.Sla)
+	}
+	if current.TokenLifetime != utilities.RoundToSeconds(desired.TokenLifetime) {
+		update.TokenLifetime = utilities.NewOptional(desired.TokenLifetime)
+	}
+	if current.Public != desired.Public {
+		update.Public = utilities.NewOptional(desired.Public)
+	}
+	if desired.Metadata != nil && !desired.Metadata.Equal(current.Metadata) {
+		update.Metadata = utilities.NewOptional(desired.Metadata)
+	}
+
+	return update, nil
+}
+
+// IsEmpty reports whether the update changes nothing
+func (si *UpdateScriptInput) IsEmpty() bool {
+	return !si.Name.IsSet() &&
+		!si.Description.IsSet() &&
+		!si.PriceInCents.IsSet() &&
+		!si.Sla.IsSet() &&
+		!si.TokenLifetime.IsSet() &&
+		!si.Public.IsSet() &&
+		!si.Metadata.IsSet()
+}
+
+// ScriptGroupChanges returns an update containing only the fields of desired
+// that differ from current.  Nil desired metadata leaves the script group's
+// metadata as it is.
+func ScriptGroupChanges(current gql.ScriptGroupProfile, desired CreateScriptGroupInput) UpdateScriptGroupInput {
+	var update UpdateScriptGroupInput
+	if current.Name != desired.Name {
+		update.Name = utilities.NewOptional(desired.Name)
+	}
+	if current.Description != desired.Description {
+		update.Description = utilities.NewOptional(desired.Description)
+	}
+	if current.Public != desired.Public {
+		update.Public = utilities.NewOptional(desired.Public)
+	}
+	if desired.Metadata != nil && !desired.Metadata.Equal(current.Metadata) {
+		update.Metadata = utilities.NewOptional(desired.Metadata)
+	}
+
+	return update
+}
+
+// IsEmpty reports whether the update changes nothing
+func (sgi *UpdateScriptGroupInput) IsEmpty() bool {
+	return !sgi.AltID.IsSet() &&
+		!sgi.Name.IsSet() &&
+		!sgi.Description.IsSet() &&
+		!sgi.Public.IsSet() &&
+		!sgi.Metadata.IsSet()
+}
+
+// Upsert creates the script if it does not exist, otherwise it updates only
+// the fields that differ from desired.  The update is conditional on the
+// version that was read, so a concurrent change returns a *ConflictError.
+func (s *Script) Upsert(ctx context.Context, desired CreateScriptInput) (UpsertAction, error) {
+	if desired.AltID == "" {
+		desired.AltID = s.AltID.String()
+	} else if desired.AltID != s.AltID.String() {
+		return "", fmt.Errorf("upsert alt id %s does not match script alt id %s", desired.AltID, s.AltID)
+	}
+
+	if err := desired.Validate(); err != nil {
+		return "", err
+	}
+
+	current, err := s.Read(ctx)
+	if errors.Is(err, ErrNotFound) {
+		if _, err := s.Create(ctx, desired); err != nil {
+			return "", err
+		}
+		return UpsertCreated, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	update, err := ScriptChanges(*current, desired)
+	if err != nil {
+		return "", err
+	}
+
+	if update.IsEmpty() {
+		return UpsertUnchanged, nil
+	}
+
+	update.IfMatch = &current.Version
+	if _, err := s.Update(ctx, update); err != nil {
+		return "", err
+	}
+
+	return UpsertUpdated, nil
+}
+
+// Upsert creates the script group if it does not exist, otherwise it updates
+// only the fields that differ from desired.  The update is conditional on the
+// version that was read, so a concurrent change returns a *ConflictError.
+func (sg *ScriptGroup) Upsert(ctx context.Context, desired CreateScriptGroupInput) (UpsertAction, error) {
+	if err := desired.Validate(); err != nil {
+		return "", err
+	}
+
+	current, err := sg.Read(ctx)
+	if errors.Is(err, ErrNotFound) {
+		if _, err := sg.Create(ctx, desired); err != nil {
+			return "", err
+		}
+		return UpsertCreated, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	update := ScriptGroupChanges(*current, desired)
+	if update.IsEmpty() {
+		return UpsertUnchanged, nil
+	}
+
+	update.IfMatch = &current.Version
+	if _, err := sg.Update(ctx, update); err != nil {
+		return "", err
+	}
+
+	return UpsertUpdated, nil
+}
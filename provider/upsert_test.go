@@ -0,0 +1,106 @@
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/myscribae/myscribae-sdk-go/provider"
+	"github.com/myscribae/myscribae-sdk-go/test_utils"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func TestScriptUpsert(t *testing.T) {
+	test_utils.SetupTest(t, func(ctx context.Context, prov *provider.Provider) error {
+		sg, err := prov.ScriptGroup("test_upsert")
+		if err != nil {
+			return err
+		}
+
+		groupInput := provider.CreateScriptGroupInput{
+			Name:        "Test Upsert Script Group",
+			Description: "This is a test script group for upserts",
+			Public:      true,
+		}
+
+		action, err := sg.Upsert(ctx, groupInput)
+		if err != nil {
+			return err
+		}
+
+		if action != provider.UpsertCreated {
+			return fmt.Errorf("expected script group to be created, got %s", action)
+		}
+
+		action, err = sg.Upsert(ctx, groupInput)
+		if err != nil {
+			return err
+		}
+
+		if action != provider.UpsertUnchanged {
+			return fmt.Errorf("expected script group to be unchanged, got %s", action)
+		}
+
+		script, err := prov.Script(sg.AltID, "upserted")
+		if err != nil {
+			return err
+		}
+
+		scriptInput := provider.CreateScriptInput{
+			Name:          "Test Upserted Script",
+			Description:   "This is a test script for upserts",
+			Recurrence:    utilities.Monthly,
+			PriceInCents:  100,
+			Sla:           100 * time.Second,
+			TokenLifetime: 100 * time.Second,
+			Public:        true,
+		}
+
+		action, err = script.Upsert(ctx, scriptInput)
+		if err != nil {
+			return err
+		}
+
+		if action != provider.UpsertCreated {
+			return fmt.Errorf("expected script to be created, got %s", action)
+		}
+
+		scriptInput.PriceInCents = 200
+		action, err = script.Upsert(ctx, scriptInput)
+		if err != nil {
+			return err
+		}
+
+		if action != provider.UpsertUpdated {
+			return fmt.Errorf("expected script to be updated, got %s", action)
+		}
+
+		// recurrence is fixed at creation
+		scriptInput.Recurrence = utilities.Yearly
+		if _, err := script.Upsert(ctx, scriptInput); !errors.Is(err, provider.ErrImmutableField) {
+			return fmt.Errorf("expected immutable field error, got %v", err)
+		}
+
+		if err := script.Delete(ctx); err != nil {
+			return err
+		}
+
+		return sg.Delete(ctx)
+	})
+}
+
+func TestScriptGroupUpsertValidatesFirst(t *testing.T) {
+	// a provider without a client fails if the upsert reaches the api
+	prov := &provider.Provider{}
+	sg, err := prov.ScriptGroup("my_group")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var validationErr *provider.ValidationError
+	if _, err := sg.Upsert(context.Background(), provider.CreateScriptGroupInput{}); !errors.As(err, &validationErr) {
+		t.Errorf("expected a *ValidationError, got %v", err)
+	}
+}
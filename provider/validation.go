@@ -0,0 +1,293 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+const (
+	MaxNameLength        = 100
+	MaxDescriptionLength = 5000
+	MaxUrlLength         = 2048
+	MaxMetadataKeys      = 50
+	MaxMetadataKeyLength = 40
+	MaxMetadataValue     = 500
+	// MaxPriceInCents is the highest price a script can have, $100,000.00
+	MaxPriceInCents utilities.MoneyValue = 10_000_000
+
+	// MaxSla and MaxTokenLifetime bound the durations of a script, which must
+	// also be at least a second once rounded to whole seconds
+	MaxSla           = 30 * 24 * time.Hour
+	MaxTokenLifetime = 365 * 24 * time.Hour
+)
+
+var ErrValidation = errors.New("invalid input")
+
+var hexColorRegexp = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// FieldViolation is a single invalid field of an input
+type FieldViolation struct {
+	// Field is the json path of the field, for example (sla_sec)
+	Field   string
+	Message string
+}
+
+func (v FieldViolation) String() string {
+	return v.Field + ": " + v.Message
+}
+
+// ValidationError lists every invalid field of an input.  It matches
+// ErrValidation with errors.Is.
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	violations := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		violations[i] = violation.String()
+	}
+
+	return fmt.Sprintf("%s: %s", ErrValidation, strings.Join(violations, "; "))
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Prefix returns a copy of the error with every field nested under path,
+// for example (script_groups[0].scripts[1])
+func (e *ValidationError) Prefix(path string) *ValidationError {
+	prefixed := &ValidationError{Violations: make([]FieldViolation, len(e.Violations))}
+	for i, violation := range e.Violations {
+		prefixed.Violations[i] = FieldViolation{Field: path + "." + violation.Field, Message: violation.Message}
+	}
+
+	return prefixed
+}
+
+// validator collects the violations of an input
+type validator struct {
+	violations []FieldViolation
+}
+
+func (v *validator) add(field, format string, args ...interface{}) {
+	v.violations = append(v.violations, FieldViolation{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: v.violations}
+}
+
+func (v *validator) text(field, value string, required bool, max int) {
+	switch {
+	case required && strings.TrimSpace(value) == "":
+		v.add(field, "is required")
+	case utf8.RuneCountInString(value) > max:
+		v.add(field, "must be at most %d characters", max)
+	}
+}
+
+func (v *validator) altID(field, value string) {
+	if value == "" {
+		v.add(field, "is required")
+	} else if err := utilities.AltIDValidation().Validate(value); err != nil {
+		v.add(field, "must be a lower snake case alt id like (my_script)")
+	}
+}
+
+func (v *validator) color(field, value string) {
+	if !hexColorRegexp.MatchString(value) {
+		v.add(field, "must be a hex color like (#1a2b3c)")
+	}
+}
+
+func (v *validator) url(field, value string) {
+	parsed, err := url.ParseRequestURI(value)
+	switch {
+	case err != nil || parsed.Host == "":
+		v.add(field, "must be an absolute url")
+	case parsed.Scheme != "http" && parsed.Scheme != "https":
+		v.add(field, "must be an http or https url")
+	case len(value) > MaxUrlLength:
+		v.add(field, "must be at most %d characters", MaxUrlLength)
+	}
+}
+
+// duration checks a duration is between a second and max once rounded to
+// the whole seconds sent to the api
+func (v *validator) duration(field string, value time.Duration, max time.Duration) {
+	rounded := utilities.RoundToSeconds(value)
+	switch {
+	case rounded < time.Second:
+		v.add(field, "must be at least 1s")
+	case rounded > max:
+		v.add(field, "must be at most %s", max)
+	}
+}
+
+func (v *validator) price(field string, value utilities.MoneyValue) {
+	if value > MaxPriceInCents {
+		v.add(field, "must be at most %s", MaxPriceInCents)
+	}
+}
+
+func (v *validator) metadata(field string, value utilities.Metadata) {
+	if len(value) > MaxMetadataKeys {
+		v.add(field, "must have at most %d keys", MaxMetadataKeys)
+	}
+
+	// sorted, so the violations are reported in a stable order
+	for _, key := range value.Keys() {
+		tag := value[key]
+		switch {
+		case strings.TrimSpace(key) == "":
+			v.add(field, "keys cannot be empty")
+		case utf8.RuneCountInString(key) > MaxMetadataKeyLength:
+			v.add(field+"."+key, "key must be at most %d characters", MaxMetadataKeyLength)
+		case utf8.RuneCountInString(tag) > MaxMetadataValue:
+			v.add(field+"."+key, "must be at most %d characters", MaxMetadataValue)
+		}
+	}
+}
+
+func (v *validator) recurrence(field string, value utilities.Recurrence) {
+	if value.IsZero() {
+		v.add(field, "is required")
+	} else if err := value.Validate(); err != nil {
+		v.add(field, "%s", err)
+	}
+}
+
+// optional runs check on the value of a set optional, and reports clearing a
+// field that is not nullable
+func optional[T any](v *validator, field string, value utilities.Optional[T], nullable bool, check func(T)) {
+	if !value.IsSet() {
+		return
+	}
+
+	if value.IsNull() {
+		if !nullable {
+			v.add(field, "cannot be cleared")
+		}
+		return
+	}
+
+	val, _ := value.Get()
+	check(val)
+}
+
+// Validate checks the input before it is sent to the api, returning a
+// *ValidationError with every invalid field
+func (si *CreateScriptInput) Validate() error {
+	var v validator
+	v.altID("alt_id", si.AltID)
+	v.text("name", si.Name, true, MaxNameLength)
+	v.text("description", si.Description, false, MaxDescriptionLength)
+	v.recurrence("recurrence", si.Recurrence)
+	v.price("price_in_cents", si.PriceInCents)
+	v.duration("sla_sec", si.Sla, MaxSla)
+	v.duration("token_lifetime_sec", si.TokenLifetime, MaxTokenLifetime)
+	v.metadata("metadata", si.Metadata)
+	return v.err()
+}
+
+// Validate checks the set fields of the input before it is sent to the api,
+// returning a *ValidationError with every invalid field
+func (si *UpdateScriptInput) Validate() error {
+	var v validator
+	optional(&v, "name", si.Name, false, func(name string) { v.text("name", name, true, MaxNameLength) })
+	optional(&v, "description", si.Description, false, func(description string) {
+		v.text("description", description, false, MaxDescriptionLength)
+	})
+	optional(&v, "price_in_cents", si.PriceInCents, false, func(price utilities.MoneyValue) { v.price("price_in_cents", price) })
+	optional(&v, "sla_sec", si.Sla, false, func(sla time.Duration) { v.duration("sla_sec", sla, MaxSla) })
+	optional(&v, "token_lifetime_sec", si.TokenLifetime, false, func(lifetime time.Duration) {
+		v.duration("token_lifetime_sec", lifetime, MaxTokenLifetime)
+	})
+	optional(&v, "public", si.Public, false, func(bool) {})
+	optional(&v, "metadata", si.Metadata, true, func(metadata utilities.Metadata) { v.metadata("metadata", metadata) })
+	return v.err()
+}
+
+// Validate checks the input before it is sent to the api, returning a
+// *ValidationError with every invalid field
+func (sgi *CreateScriptGroupInput) Validate() error {
+	var v validator
+	v.text("name", sgi.Name, true, MaxNameLength)
+	v.text("description", sgi.Description, false, MaxDescriptionLength)
+	v.metadata("metadata", sgi.Metadata)
+	return v.err()
+}
+
+// Validate checks the set fields of the input before it is sent to the api,
+// returning a *ValidationError with every invalid field
+func (sgi *UpdateScriptGroupInput) Validate() error {
+	var v validator
+	optional(&v, "alt_id", sgi.AltID, false, func(altID string) { v.altID("alt_id", altID) })
+	optional(&v, "name", sgi.Name, false, func(name string) { v.text("name", name, true, MaxNameLength) })
+	optional(&v, "description", sgi.Description, false, func(description string) {
+		v.text("description", description, false, MaxDescriptionLength)
+	})
+	optional(&v, "public", sgi.Public, false, func(bool) {})
+	optional(&v, "metadata", sgi.Metadata, true, func(metadata utilities.Metadata) { v.metadata("metadata", metadata) })
+	return v.err()
+}
+
+// Validate checks the input before it is sent to the api, returning a
+// *ValidationError with every invalid field
+func (pi *CreateProviderProfileInput) Validate() error {
+	var v validator
+	if pi.AltID != nil {
+		v.altID("alt_id", *pi.AltID)
+	}
+	v.text("name", pi.Name, true, MaxNameLength)
+	if pi.CategoryID != nil {
+		v.text("category_id", *pi.CategoryID, true, MaxNameLength)
+	}
+	v.text("description", pi.Description, false, MaxDescriptionLength)
+	if pi.LogoUrl != nil {
+		v.url("logo_url", *pi.LogoUrl)
+	}
+	if pi.BannerUrl != nil {
+		v.url("banner_url", *pi.BannerUrl)
+	}
+	if pi.Url != nil {
+		v.url("url", *pi.Url)
+	}
+	if pi.Color != nil {
+		v.color("color", *pi.Color)
+	}
+	return v.err()
+}
+
+// Validate checks the set fields of the input before it is sent to the api,
+// returning a *ValidationError with every invalid field
+func (pi *UpdateProviderProfileInput) Validate() error {
+	var v validator
+	optional(&v, "alt_id", pi.AltID, false, func(altID string) { v.altID("alt_id", altID) })
+	optional(&v, "name", pi.Name, false, func(name string) { v.text("name", name, true, MaxNameLength) })
+	optional(&v, "category_id", pi.CategoryID, false, func(category string) {
+		v.text("category_id", category, true, MaxNameLength)
+	})
+	optional(&v, "description", pi.Description, false, func(description string) {
+		v.text("description", description, false, MaxDescriptionLength)
+	})
+	optional(&v, "logo_url", pi.LogoUrl, true, func(logo string) { v.url("logo_url", logo) })
+	optional(&v, "banner_url", pi.BannerUrl, true, func(banner string) { v.url("banner_url", banner) })
+	optional(&v, "url", pi.Url, true, func(u string) { v.url("url", u) })
+	optional(&v, "color", pi.Color, true, func(color string) { v.color("color", color) })
+	optional(&v, "public", pi.Public, false, func(bool) {})
+	optional(&v, "account_service", pi.AccountService, false, func(bool) {})
+	return v.err()
+}
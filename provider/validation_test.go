@@ -0,0 +1,122 @@
+package provider_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/myscribae/myscribae-sdk-go/provider"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func violatedFields(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *provider.ValidationError
+	if !errors.As(err, &validationErr) || !errors.Is(err, provider.ErrValidation) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+
+	fields := make([]string, len(validationErr.Violations))
+	for i, violation := range validationErr.Violations {
+		fields[i] = violation.Field
+	}
+	return fields
+}
+
+func TestValidateInputs(t *testing.T) {
+	badUrl := "ftp://example.com/logo.png"
+	badColor := "blue"
+	goodColor := "#1a2b3c"
+
+	tests := []struct {
+		name     string
+		input    interface{ Validate() error }
+		expected []string
+	}{
+		{
+			"valid script",
+			&provider.CreateScriptInput{
+				AltID:         "plan_v2",
+				Name:          "Summarize",
+				Recurrence:    utilities.Monthly,
+				PriceInCents:  500,
+				Sla:           time.Minute,
+				TokenLifetime: time.Hour,
+			},
+			nil,
+		},
+		{
+			"invalid script reports every field",
+			&provider.CreateScriptInput{
+				AltID:        "Plan V2",
+				Name:         strings.Repeat("a", provider.MaxNameLength+1),
+				PriceInCents: provider.MaxPriceInCents + 1,
+			},
+			[]string{"alt_id", "name", "recurrence", "price_in_cents", "sla_sec", "token_lifetime_sec"},
+		},
+		{
+			"script update only checks set fields",
+			&provider.UpdateScriptInput{
+				Sla:  utilities.NewOptional(400 * time.Millisecond),
+				Name: utilities.NullOptional[string](),
+			},
+			[]string{"name", "sla_sec"},
+		},
+		{
+			"script group metadata limits",
+			&provider.UpdateScriptGroupInput{
+				Metadata: utilities.NewOptional(utilities.Metadata{
+					"team":        strings.Repeat("a", provider.MaxMetadataValue+1),
+					"cost_center": strings.Repeat("a", provider.MaxMetadataValue+1),
+					"region":      strings.Repeat("a", provider.MaxMetadataValue+1),
+				}),
+			},
+			[]string{"metadata.cost_center", "metadata.region", "metadata.team"},
+		},
+		{
+			"script group requires a name",
+			&provider.CreateScriptGroupInput{Name: "  "},
+			[]string{"name"},
+		},
+		{
+			"provider urls and color",
+			&provider.CreateProviderProfileInput{
+				Name:    "Provider",
+				LogoUrl: &badUrl,
+				Color:   &badColor,
+			},
+			[]string{"logo_url", "color"},
+		},
+		{
+			"provider update clears nullable fields",
+			&provider.UpdateProviderProfileInput{
+				LogoUrl: utilities.NullOptional[string](),
+				Color:   utilities.NewOptional(goodColor),
+				Url:     utilities.NewOptional("not a url"),
+			},
+			[]string{"url"},
+		},
+		{
+			"provider update cannot clear the alt id",
+			&provider.UpdateProviderProfileInput{
+				AltID: utilities.NullOptional[string](),
+			},
+			[]string{"alt_id"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fields := violatedFields(t, test.input.Validate())
+			if strings.Join(fields, ",") != strings.Join(test.expected, ",") {
+				t.Errorf("expected violations of %v, got %v", test.expected, fields)
+			}
+		})
+	}
+}
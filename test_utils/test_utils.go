@@ -2,46 +2,48 @@ package test_utils
 
 import (
 	"context"
-	"log"
-	"os"
 	"testing"
 
 	"github.com/google/uuid"
-	"github.com/joho/godotenv"
 	"github.com/myscribae/myscribae-sdk-go/gql"
 	"github.com/myscribae/myscribae-sdk-go/provider"
 )
 
 const (
 	TestProviderUuid = "00000000-0000-0000-0000-000000000001"
+	// TestConfigFile is the config of the test api, relative to the package
+	// being tested
+	TestConfigFile = "../.env"
 )
 
-func PreTest() {
-	err := godotenv.Load("../.env")
+// SetupTest runs tf against the test api, skipping the test when no config
+// of the test api is found
+func SetupTest(t *testing.T, tf func(ctx context.Context, prov *provider.Provider) error) {
+	t.Helper()
+
+	loaded, err := provider.LoadConfig(provider.ConfigOptions{File: TestConfigFile})
 	if err != nil {
-		log.Fatalf("Error loading .env file: %s", err)
+		t.Skipf("no test api config: %s", err)
 	}
-}
 
-func SetupTest(t *testing.T, tf func(ctx context.Context, prov *provider.Provider) error) {
-	PreTest()
-	var (
-		apiUrl   = os.Getenv("MYSCRIBAE_API_URL")
-		apiToken = os.Getenv("MYSCRIBAE_API_TOKEN")
-	)
+	config := loaded.ProviderConfig()
+	if config.ApiToken == nil {
+		t.Skipf("no test api token in %s", TestConfigFile)
+	}
 
 	client := gql.CreateGraphQLClient(
-		apiUrl,
-		&apiToken,
+		*config.ApiUrl,
+		config.ApiToken,
 	)
 
 	prov := provider.Provider{
-		ApiUrl: apiUrl,
-		Uuid:   uuid.MustParse(TestProviderUuid),
-		Client: client,
+		ApiUrl:   *config.ApiUrl,
+		Uuid:     uuid.MustParse(TestProviderUuid),
+		ApiToken: config.ApiToken,
+		Client:   client,
 	}
 	ctx := context.Background()
-	err := tf(ctx, &prov)
+	err = tf(ctx, &prov)
 	if err != nil {
 		t.Errorf("Test failed: %s", err)
 	}
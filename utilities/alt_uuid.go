@@ -1,14 +1,64 @@
 package utilities
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"sync/atomic"
 
 	"github.com/google/uuid"
 )
 
+// AltUuid identifies a resource either by its uuid or by an alt id chosen by
+// the provider, like (my_script) or (plan_v2)
 type AltUuid string
 
+// AltIDRules are the rules alt ids are validated against
+type AltIDRules struct {
+	// Pattern the alt id must match
+	Pattern *regexp.Regexp
+	// MaxLength is the maximum length of the alt id in bytes, or 0 for no limit
+	MaxLength int
+}
+
+// DefaultAltIDRules allow lower snake case of letters and digits, starting
+// with a letter, like (my_script), (plan_v2) or (tier_2), of up to 64 characters
+var DefaultAltIDRules = AltIDRules{
+	Pattern:   regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`),
+	MaxLength: 64,
+}
+
+var altIDValidation atomic.Pointer[AltIDRules]
+
+func init() {
+	SetAltIDValidation(DefaultAltIDRules)
+}
+
+// AltIDValidation returns the rules NewAltUuid validates alt ids against
+func AltIDValidation() AltIDRules {
+	return *altIDValidation.Load()
+}
+
+// SetAltIDValidation replaces the rules NewAltUuid validates alt ids against,
+// for apis that are configured differently.  It is safe to call while alt ids
+// are being validated.
+func SetAltIDValidation(rules AltIDRules) {
+	altIDValidation.Store(&rules)
+}
+
+// Validate checks an alt id against the rules
+func (r AltIDRules) Validate(altID string) error {
+	if r.MaxLength > 0 && len(altID) > r.MaxLength {
+		return fmt.Errorf("invalid alt id.  expecting at most %d characters. received: %s", r.MaxLength, altID)
+	}
+	if r.Pattern != nil && !r.Pattern.MatchString(altID) {
+		return errInvalidAltUuid(altID)
+	}
+
+	return nil
+}
+
 func errInvalidAltUuid(val string) error {
 	return fmt.Errorf("invalid alt id or uuid.  expecting format like (12345678-1234-1234-1234-123456789abc) or (my_alt_id). received: %s", val)
 }
@@ -27,9 +77,9 @@ func NewAltUuidPointer(altIdOrUuid *string) (*AltUuid, error) {
 
 	// check if is uuid
 	if _, err := uuid.Parse(*altIdOrUuid); err != nil {
-		// is not uuid, check if lower, snake case
-		if !isLowerSnakeCase(*altIdOrUuid) {
-			return nil, errInvalidAltUuid(*altIdOrUuid)
+		// is not uuid, check it is a valid alt id
+		if err := AltIDValidation().Validate(*altIdOrUuid); err != nil {
+			return nil, err
 		}
 	}
 
@@ -37,6 +87,34 @@ func NewAltUuidPointer(altIdOrUuid *string) (*AltUuid, error) {
 	return &parsed, nil
 }
 
+// AltUuidFromUUID converts a uuid without validating or parsing it
+func AltUuidFromUUID(id uuid.UUID) AltUuid {
+	return AltUuid(id.String())
+}
+
+// IsUUID reports whether the AltUuid holds a uuid rather than an alt id
+func (u AltUuid) IsUUID() bool {
+	_, ok := u.UUID()
+	return ok
+}
+
+// UUID returns the uuid, and false when the AltUuid holds an alt id
+func (u AltUuid) UUID() (uuid.UUID, bool) {
+	id, err := uuid.Parse(string(u))
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// AltID returns the alt id, and false when the AltUuid holds a uuid
+func (u AltUuid) AltID() (string, bool) {
+	if u == "" || u.IsUUID() {
+		return "", false
+	}
+	return string(u), true
+}
+
 func (u AltUuid) String() string {
 	return string(u)
 }
@@ -45,7 +123,51 @@ func (u *AltUuid) GetGraphQLType() string {
 	return "AltUuid"
 }
 
-func isLowerSnakeCase(s string) bool {
-	match, _ := regexp.MatchString(`^[a-z]+(_[a-z]+)*$`, s)
-	return match
+func (u AltUuid) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(u))
+}
+
+// UnmarshalJSON decodes and validates an alt id or uuid
+func (u *AltUuid) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	return u.UnmarshalText([]byte(s))
+}
+
+func (u AltUuid) MarshalText() ([]byte, error) {
+	return []byte(u), nil
+}
+
+// UnmarshalText decodes and validates an alt id or uuid
+func (u *AltUuid) UnmarshalText(text []byte) error {
+	parsed, err := NewAltUuid(string(text))
+	if err != nil {
+		return err
+	}
+
+	*u = parsed
+	return nil
+}
+
+func (u AltUuid) Value() (driver.Value, error) {
+	return string(u), nil
+}
+
+// Scan reads and validates an alt id or uuid.  Use sql.Null[AltUuid] for nullable columns.
+func (u *AltUuid) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return u.UnmarshalText([]byte(v))
+	case []byte:
+		return u.UnmarshalText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into an alt id or uuid", src)
+	}
 }
@@ -0,0 +1,97 @@
+package utilities_test
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func TestNewAltUuid(t *testing.T) {
+	tests := []struct {
+		input  string
+		valid  bool
+		isUUID bool
+	}{
+		{"my_script", true, false},
+		{"plan_v2", true, false},
+		{"tier_2", true, false},
+		{"12345678-1234-1234-1234-123456789abc", true, true},
+		{"2_tier", false, false},
+		{"My_Script", false, false},
+		{"my__script", false, false},
+		{"my_script_", false, false},
+		{"my-script", false, false},
+		{strings.Repeat("a", 64), true, false},
+		{strings.Repeat("a", 65), false, false},
+		{"", false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			id, err := utilities.NewAltUuid(test.input)
+			if (err == nil) != test.valid {
+				t.Fatalf("expected valid to be %t, got %v", test.valid, err)
+			}
+
+			if !test.valid {
+				return
+			}
+
+			if id.IsUUID() != test.isUUID {
+				t.Errorf("expected IsUUID to be %t", test.isUUID)
+			}
+
+			altID, ok := id.AltID()
+			if ok == test.isUUID || (ok && altID != test.input) {
+				t.Errorf("unexpected alt id %q", altID)
+			}
+		})
+	}
+}
+
+func TestAltUuidFromUUID(t *testing.T) {
+	expected := uuid.New()
+	id := utilities.AltUuidFromUUID(expected)
+
+	result, ok := id.UUID()
+	if !ok || result != expected {
+		t.Errorf("expected %s, got %s", expected, result)
+	}
+
+	if _, ok := id.AltID(); ok {
+		t.Errorf("expected a uuid to have no alt id")
+	}
+}
+
+func TestAltIDValidation(t *testing.T) {
+	defer utilities.SetAltIDValidation(utilities.DefaultAltIDRules)
+
+	utilities.SetAltIDValidation(utilities.AltIDRules{
+		Pattern:   regexp.MustCompile(`^[a-z-]+$`),
+		MaxLength: 8,
+	})
+
+	if _, err := utilities.NewAltUuid("my-plan"); err != nil {
+		t.Errorf("expected custom rules to allow dashes, got %v", err)
+	}
+
+	if _, err := utilities.NewAltUuid("my-long-plan"); err == nil {
+		t.Errorf("expected custom rules to limit the length")
+	}
+
+	// the rules can be replaced while alt ids are validated
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			utilities.NewAltUuid("my-plan")
+		}()
+	}
+	utilities.SetAltIDValidation(utilities.DefaultAltIDRules)
+	wg.Wait()
+}
@@ -1,17 +1,33 @@
 package utilities
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 )
 
-// MoneyValue is a type that represents a value in cents
+// MoneyValue is a type that represents a value in cents.  Every codec uses a
+// whole number of cents: json numbers, text like (1999) and sql integers.
+// String formats the value in dollars for display, and NewMoneyValueFromString
+// parses dollar amounts.
 type MoneyValue uint
 
-// NewMoneyValue creates a new MoneyValue from a float64
+// NewDollarValue creates a new MoneyValue from a float64, rounded to the
+// nearest cent.  Negative and NaN values are 0, and values too large for a
+// MoneyValue are clamped to the largest one.
 func NewDollarValue(value float64) MoneyValue {
-	return MoneyValue(value * 100)
+	if value <= 0 || math.IsNaN(value) {
+		return 0
+	}
+
+	cents := math.Round(value * 100)
+	if cents >= float64(math.MaxUint) {
+		return MoneyValue(math.MaxUint)
+	}
+	return MoneyValue(cents)
 }
 
 // NewMoneyValue creates a new MoneyValue from a float64
@@ -19,33 +35,18 @@ func NewCentValue(value uint) MoneyValue {
 	return MoneyValue(value)
 }
 
+// Deprecated: FloatRegexp is no longer used to parse money values, see ParseMoney
 var FloatRegexp = regexp.MustCompile(`^-?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?$`)
 
-// NewMoneyValueFromString creates a new MoneyValue from a string
+// NewMoneyValueFromString creates a new MoneyValue from a dollar amount like
+// (12.34) or (12), parsed exactly without going through a float
 func NewMoneyValueFromString(value string) (MoneyValue, error) {
-	// check if a decimal point is present
-	if len(value) == 0 {
-		return 0, fmt.Errorf("invalid value")
-	}
-
-	// check if a decimal point is present
-	if FloatRegexp.MatchString(value) {
-		// is float
-		f, err := strconv.ParseFloat(value, 64)
-		if err != nil {
-			return 0, err
-		}
-
-		return NewDollarValue(f), nil
-	}
-
-	// is int
-	f, err := strconv.ParseUint(value, 10, 64)
+	money, err := ParseMoney(value, USD)
 	if err != nil {
 		return 0, err
 	}
 
-	return NewCentValue(uint(f)), nil
+	return money.MoneyValue()
 }
 
 // Float64 returns the value as a float64
@@ -55,13 +56,85 @@ func (c MoneyValue) Float64() float64 {
 
 // String returns the value as a string
 func (c MoneyValue) String() string {
-	return fmt.Sprintf("%.2f", c.Float64())
+	return fmt.Sprintf("%d.%02d", c/100, c%100)
 }
 
 func (c MoneyValue) CentsValue() uint {
 	return uint(c)
 }
 
+// Money returns the value as an amount of US dollars
+func (c MoneyValue) Money() Money {
+	return Money{Amount: int64(c), Currency: USD}
+}
+
 func (u MoneyValue) GetGraphQLType() string {
 	return "CentValue"
 }
+
+// MarshalJSON encodes the value as a number of cents, as the api does
+func (c MoneyValue) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatUint(uint64(c), 10)), nil
+}
+
+// UnmarshalJSON decodes a non-negative whole number of cents
+func (c *MoneyValue) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	// json.Number also accepts quoted numbers, which are ambiguous
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil || data[0] == '"' {
+		return fmt.Errorf("invalid money value.  expecting a number of cents. received: %s", data)
+	}
+
+	return c.parseCents(n.String())
+}
+
+// MarshalText encodes the value as a whole number of cents like (1234)
+func (c MoneyValue) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatUint(uint64(c), 10)), nil
+}
+
+// UnmarshalText decodes a non-negative whole number of cents like (1234)
+func (c *MoneyValue) UnmarshalText(text []byte) error {
+	return c.parseCents(string(text))
+}
+
+// Value stores the value as an integer number of cents
+func (c MoneyValue) Value() (driver.Value, error) {
+	if uint64(c) > math.MaxInt64 {
+		return nil, fmt.Errorf("money value %d overflows an int64", c)
+	}
+	return int64(c), nil
+}
+
+// Scan reads an integer number of cents, or its text.  Use
+// sql.Null[MoneyValue] for nullable columns.
+func (c *MoneyValue) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("invalid money value.  expecting a non-negative number of cents. received: %d", v)
+		}
+		*c = MoneyValue(v)
+		return nil
+	case []byte:
+		return c.parseCents(string(v))
+	case string:
+		return c.parseCents(v)
+	default:
+		return fmt.Errorf("cannot scan %T into a money value", src)
+	}
+}
+
+func (c *MoneyValue) parseCents(s string) error {
+	cents, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid money value.  expecting a non-negative whole number of cents. received: %s", s)
+	}
+
+	*c = MoneyValue(cents)
+	return nil
+}
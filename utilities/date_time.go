@@ -0,0 +1,51 @@
+package utilities
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DateTime is a time.Time sent to the api as an rfc 3339 timestamp in utc,
+// truncated to whole seconds
+type DateTime time.Time
+
+func NewDateTime(t time.Time) DateTime {
+	return DateTime(t)
+}
+
+func (d DateTime) Time() time.Time {
+	return time.Time(d)
+}
+
+func (d DateTime) String() string {
+	return time.Time(d).UTC().Format(time.RFC3339)
+}
+
+func (d DateTime) GetGraphQLType() string {
+	return "DateTime"
+}
+
+func (d DateTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes an rfc 3339 timestamp like ("2024-01-02T15:04:05Z")
+func (d *DateTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid date time.  expecting an rfc 3339 timestamp. received: %s", data)
+	}
+
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return fmt.Errorf("invalid date time.  expecting an rfc 3339 timestamp. received: %s", s)
+	}
+
+	*d = DateTime(parsed)
+	return nil
+}
@@ -0,0 +1,33 @@
+package utilities_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func TestDateTimeJSON(t *testing.T) {
+	at := time.Date(2024, 1, 2, 15, 4, 5, 0, time.FixedZone("CET", 3600))
+
+	data, err := json.Marshal(utilities.NewDateTime(at))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `"2024-01-02T14:04:05Z"` {
+		t.Errorf("expected the timestamp in utc, got %s", data)
+	}
+
+	var decoded utilities.DateTime
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Time().Equal(at) {
+		t.Errorf("expected %s, got %s", at, decoded.Time())
+	}
+
+	if err := json.Unmarshal([]byte(`"tomorrow"`), &decoded); err == nil {
+		t.Errorf("expected an invalid timestamp to be refused")
+	}
+}
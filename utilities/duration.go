@@ -0,0 +1,74 @@
+package utilities
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DurationSeconds is a time.Duration sent to the api as a whole number of
+// seconds.  Sub-second durations are rounded to the nearest second, half
+// away from zero.
+type DurationSeconds time.Duration
+
+// RoundToSeconds rounds a duration to the whole seconds the api stores
+func RoundToSeconds(d time.Duration) time.Duration {
+	return d.Round(time.Second)
+}
+
+// NewDurationFromSeconds converts a number of seconds from the api to a duration
+func NewDurationFromSeconds(seconds int64) time.Duration {
+	return time.Duration(seconds) * time.Second
+}
+
+// Seconds returns the rounded number of seconds
+func (d DurationSeconds) Seconds() int64 {
+	return int64(RoundToSeconds(time.Duration(d)) / time.Second)
+}
+
+func (d DurationSeconds) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+func (d DurationSeconds) String() string {
+	return strconv.FormatInt(d.Seconds(), 10)
+}
+
+func (d DurationSeconds) GetGraphQLType() string {
+	return "UInt"
+}
+
+func (d DurationSeconds) MarshalJSON() ([]byte, error) {
+	if d.Seconds() < 0 {
+		return nil, fmt.Errorf("invalid duration.  expecting a non-negative duration. received: %s", time.Duration(d))
+	}
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON decodes a whole number of seconds like (90), or a duration
+// string like ("1m30s")
+func (d *DurationSeconds) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		parsed, err := time.ParseDuration(s)
+		if err != nil || parsed < 0 {
+			return fmt.Errorf("invalid duration.  expecting a non-negative duration like (1m30s). received: %s", s)
+		}
+
+		*d = DurationSeconds(parsed)
+		return nil
+	}
+
+	seconds, err := strconv.ParseUint(string(data), 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid duration.  expecting a non-negative whole number of seconds. received: %s", data)
+	}
+
+	*d = DurationSeconds(NewDurationFromSeconds(int64(seconds)))
+	return nil
+}
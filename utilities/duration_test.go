@@ -0,0 +1,58 @@
+package utilities_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func TestDurationSeconds(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		seconds  int64
+	}{
+		{90 * time.Second, 90},
+		{1499 * time.Millisecond, 1},
+		{1500 * time.Millisecond, 2},
+		{400 * time.Millisecond, 0},
+		{time.Hour, 3600},
+	}
+
+	for _, test := range tests {
+		if seconds := utilities.DurationSeconds(test.duration).Seconds(); seconds != test.seconds {
+			t.Errorf("%s: expected %d seconds, got %d", test.duration, test.seconds, seconds)
+		}
+	}
+}
+
+func TestDurationSecondsJSON(t *testing.T) {
+	var input struct {
+		Sla           utilities.DurationSeconds `json:"sla_sec"`
+		TokenLifetime utilities.DurationSeconds `json:"token_lifetime_sec"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"sla_sec":90,"token_lifetime_sec":"1h"}`), &input); err != nil {
+		t.Fatal(err)
+	}
+
+	if input.Sla.Duration() != 90*time.Second || input.TokenLifetime.Duration() != time.Hour {
+		t.Errorf("unexpected durations %s and %s", input.Sla.Duration(), input.TokenLifetime.Duration())
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `{"sla_sec":90,"token_lifetime_sec":3600}` {
+		t.Errorf("unexpected json %s", data)
+	}
+
+	for _, invalid := range []string{`{"sla_sec":-1}`, `{"sla_sec":1.5}`, `{"sla_sec":"soon"}`, `{"sla_sec":"-1m"}`} {
+		if err := json.Unmarshal([]byte(invalid), &input); err == nil {
+			t.Errorf("expected %s to be invalid", invalid)
+		}
+	}
+}
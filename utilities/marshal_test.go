@@ -0,0 +1,143 @@
+package utilities_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"testing"
+
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+var (
+	_ json.Marshaler           = utilities.MoneyValue(0)
+	_ encoding.TextMarshaler   = utilities.UInt(0)
+	_ encoding.TextUnmarshaler = (*utilities.AltUuid)(nil)
+	_ driver.Valuer            = utilities.Recurrence{}
+	_ sql.Scanner              = (*utilities.Recurrence)(nil)
+)
+
+type record struct {
+	Price      utilities.MoneyValue `json:"price"`
+	Sla        utilities.UInt       `json:"sla"`
+	ID         utilities.AltUuid    `json:"id"`
+	Recurrence utilities.Recurrence `json:"recurrence"`
+}
+
+func TestValueTypesJSON(t *testing.T) {
+	input := `{"price":1999,"sla":60,"id":"my_script","recurrence":"P3M"}`
+
+	var r record
+	if err := json.Unmarshal([]byte(input), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Price != 1999 || r.Sla != 60 || r.ID != "my_script" || r.Recurrence != utilities.Every(3, utilities.RecurrenceMonth) {
+		t.Errorf("unexpected record %+v", r)
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != input {
+		t.Errorf("expected %s, got %s", input, data)
+	}
+
+	for _, invalid := range []string{
+		`{"price":-1}`,
+		`{"price":19.99}`,
+		`{"price":"1999"}`,
+		`{"sla":-5}`,
+		`{"id":"Not An Id"}`,
+		`{"recurrence":"sometimes"}`,
+	} {
+		if err := json.Unmarshal([]byte(invalid), &record{}); err == nil {
+			t.Errorf("expected %s to be invalid", invalid)
+		}
+	}
+}
+
+func TestValueTypesText(t *testing.T) {
+	// text is cents, like json and sql
+	var price utilities.MoneyValue
+	if err := price.UnmarshalText([]byte("29")); err != nil || price != 29 {
+		t.Errorf("expected 29 cents, got %d (%v)", price, err)
+	}
+
+	text, _ := price.MarshalText()
+	if string(text) != "29" {
+		t.Errorf("expected 29, got %s", text)
+	}
+
+	var scanned utilities.MoneyValue
+	if err := scanned.Scan(string(text)); err != nil || scanned != price {
+		t.Errorf("expected the text to scan to %d cents, got %d (%v)", price, scanned, err)
+	}
+	if err := price.UnmarshalText([]byte("0.29")); err == nil {
+		t.Errorf("expected a dollar amount to be refused as text")
+	}
+
+	// text marshalers also cover map keys
+	data, err := json.Marshal(map[utilities.AltUuid]utilities.UInt{"my_script": 3})
+	if err != nil || string(data) != `{"my_script":3}` {
+		t.Errorf("unexpected map json %s (%v)", data, err)
+	}
+
+	var ids map[utilities.AltUuid]utilities.UInt
+	if err := json.Unmarshal([]byte(`{"Invalid Key":3}`), &ids); err == nil {
+		t.Errorf("expected an invalid alt id error")
+	}
+}
+
+func TestValueTypesSQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		scanner sql.Scanner
+		src     any
+		valid   bool
+	}{
+		{"money from int64", new(utilities.MoneyValue), int64(1999), true},
+		{"money from bytes", new(utilities.MoneyValue), []byte("1999"), true},
+		{"negative money", new(utilities.MoneyValue), int64(-1), false},
+		{"money from nil", new(utilities.MoneyValue), nil, false},
+		{"uint from int64", new(utilities.UInt), int64(60), true},
+		{"negative uint", new(utilities.UInt), int64(-60), false},
+		{"alt id from string", new(utilities.AltUuid), "my_script", true},
+		{"uuid from bytes", new(utilities.AltUuid), []byte("12345678-1234-1234-1234-123456789abc"), true},
+		{"invalid alt id", new(utilities.AltUuid), "My Script", false},
+		{"recurrence from string", new(utilities.Recurrence), "monthly;trial=P14D", true},
+		{"invalid recurrence", new(utilities.Recurrence), "sometimes", false},
+		{"recurrence from int64", new(utilities.Recurrence), int64(1), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.scanner.Scan(test.src)
+			if (err == nil) != test.valid {
+				t.Fatalf("expected valid to be %t, got %v", test.valid, err)
+			}
+
+			if !test.valid {
+				return
+			}
+
+			// values round trip through the database
+			value, err := test.scanner.(driver.Valuer).Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := test.scanner.Scan(value); err != nil {
+				t.Errorf("value %v did not round trip: %v", value, err)
+			}
+		})
+	}
+
+	var nullable sql.Null[utilities.MoneyValue]
+	if err := nullable.Scan(nil); err != nil || nullable.Valid {
+		t.Errorf("expected a null money value, got %+v (%v)", nullable, err)
+	}
+}
@@ -0,0 +1,60 @@
+package utilities
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Metadata is free-form tags on a script or script group, like the owning
+// team or a cost center.  It is sent to the api as a json object.
+type Metadata map[string]string
+
+func (m Metadata) GetGraphQLType() string {
+	return "JSON"
+}
+
+// MarshalJSON encodes the metadata as a json object, which is empty for nil metadata
+func (m Metadata) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(map[string]string(m))
+}
+
+// Equal reports whether both have the same tags, treating nil as empty
+func (m Metadata) Equal(other Metadata) bool {
+	if len(m) != len(other) {
+		return false
+	}
+
+	for key, value := range m {
+		if otherValue, ok := other[key]; !ok || otherValue != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Keys returns the keys of the tags, sorted
+func (m Metadata) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// String formats the tags sorted by key, for example (cost_center=42, team=search)
+func (m Metadata) String() string {
+	keys := m.Keys()
+	tags := make([]string, len(keys))
+	for i, key := range keys {
+		tags[i] = key + "=" + m[key]
+	}
+
+	return strings.Join(tags, ", ")
+}
@@ -0,0 +1,31 @@
+package utilities_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func TestMetadata(t *testing.T) {
+	metadata := utilities.Metadata{"team": "search", "cost_center": "42"}
+
+	if s := metadata.String(); s != "cost_center=42, team=search" {
+		t.Errorf("expected sorted tags, got %s", s)
+	}
+
+	if !utilities.Metadata(nil).Equal(utilities.Metadata{}) {
+		t.Error("expected nil metadata to equal empty metadata")
+	}
+	if metadata.Equal(utilities.Metadata{"team": "search"}) {
+		t.Error("expected metadata with different tags to differ")
+	}
+
+	data, err := json.Marshal(utilities.Metadata(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "{}" {
+		t.Errorf("expected nil metadata to encode as {}, got %s", data)
+	}
+}
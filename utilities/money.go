@@ -0,0 +1,318 @@
+package utilities
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrCurrencyMismatch = errors.New("currencies do not match")
+	ErrMoneyOverflow    = errors.New("money amount overflows")
+)
+
+// Currency is an iso 4217 currency code, for example (USD)
+type Currency string
+
+const (
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+	GBP Currency = "GBP"
+	CAD Currency = "CAD"
+	AUD Currency = "AUD"
+	CHF Currency = "CHF"
+	JPY Currency = "JPY"
+	KRW Currency = "KRW"
+	CNY Currency = "CNY"
+	INR Currency = "INR"
+	MXN Currency = "MXN"
+	BRL Currency = "BRL"
+	SEK Currency = "SEK"
+	BHD Currency = "BHD"
+	KWD Currency = "KWD"
+	JOD Currency = "JOD"
+	OMR Currency = "OMR"
+	TND Currency = "TND"
+)
+
+type currencyInfo struct {
+	minorUnits int
+	symbol     string
+}
+
+var currencies = map[Currency]currencyInfo{
+	USD: {2, "$"},
+	EUR: {2, "€"},
+	GBP: {2, "£"},
+	CAD: {2, "CA$"},
+	AUD: {2, "A$"},
+	CHF: {2, "CHF"},
+	JPY: {0, "¥"},
+	KRW: {0, "₩"},
+	CNY: {2, "CN¥"},
+	INR: {2, "₹"},
+	MXN: {2, "MX$"},
+	BRL: {2, "R$"},
+	SEK: {2, "kr"},
+	BHD: {3, "BHD"},
+	KWD: {3, "KWD"},
+	JOD: {3, "JOD"},
+	OMR: {3, "OMR"},
+	TND: {3, "TND"},
+}
+
+// NewCurrency parses an iso 4217 currency code, ignoring case
+func NewCurrency(code string) (Currency, error) {
+	currency := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if _, ok := currencies[currency]; !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownCurrency, code)
+	}
+
+	return currency, nil
+}
+
+// MinorUnits returns the number of decimal places of the currency, for
+// example 2 for USD, 0 for JPY and 3 for BHD
+func (c Currency) MinorUnits() int {
+	return currencies[c].minorUnits
+}
+
+// Symbol returns the symbol of the currency, or its code when it has none
+func (c Currency) Symbol() string {
+	if info, ok := currencies[c]; ok {
+		return info.symbol
+	}
+	return string(c)
+}
+
+func (c Currency) String() string {
+	return string(c)
+}
+
+// Money is an exact amount of a currency, counted in its minor units
+// (cents for USD, yen for JPY, fils for BHD)
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+var decimalRegexp = regexp.MustCompile(`^(-)?(\d+)(?:\.(\d+))?$`)
+
+// ParseMoney parses a decimal amount like (12.34) of the currency exactly,
+// without going through a float.  Input that is ambiguous or cannot be
+// represented is rejected: thousands separators, exponents, missing digits
+// around the decimal point and more decimal places than the currency has.
+func ParseMoney(value string, currency Currency) (Money, error) {
+	if _, ok := currencies[currency]; !ok {
+		return Money{}, fmt.Errorf("%w: %s", ErrUnknownCurrency, currency)
+	}
+
+	m := decimalRegexp.FindStringSubmatch(value)
+	if m == nil {
+		return Money{}, fmt.Errorf("invalid money value.  expecting a decimal like (12.34). received: %q", value)
+	}
+
+	negative, whole, fraction := m[1] == "-", m[2], m[3]
+	minorUnits := currency.MinorUnits()
+	if len(fraction) > minorUnits {
+		return Money{}, fmt.Errorf("invalid money value.  %s has %d decimal places. received: %q", currency, minorUnits, value)
+	}
+
+	digits := whole + fraction + strings.Repeat("0", minorUnits-len(fraction))
+
+	var amount int64
+	for _, d := range digits {
+		var err error
+		if amount, err = mulInt64(amount, 10); err != nil {
+			return Money{}, fmt.Errorf("%w: %q", ErrMoneyOverflow, value)
+		}
+		if amount, err = addInt64(amount, int64(d-'0')); err != nil {
+			return Money{}, fmt.Errorf("%w: %q", ErrMoneyOverflow, value)
+		}
+	}
+
+	if negative {
+		amount = -amount
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// MoneyValue converts a non-negative amount of US dollars to a MoneyValue
+func (m Money) MoneyValue() (MoneyValue, error) {
+	if m.Currency != USD {
+		return 0, fmt.Errorf("%w: expecting %s, received %s", ErrCurrencyMismatch, USD, m.Currency)
+	}
+	if m.Amount < 0 {
+		return 0, fmt.Errorf("invalid money value.  expecting a non-negative amount. received: %s", m)
+	}
+
+	return MoneyValue(m.Amount), nil
+}
+
+// Add returns the sum of two amounts of the same currency
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+
+	amount, err := addInt64(m.Amount, other.Amount)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// Mul returns the amount multiplied by a quantity
+func (m Money) Mul(quantity int64) (Money, error) {
+	amount, err := mulInt64(m.Amount, quantity)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// Percent returns percent percent of the amount, rounded half away from zero
+// to the currency's minor unit
+func (m Money) Percent(percent int64) (Money, error) {
+	bp, err := mulInt64(percent, 100)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return m.BasisPoints(bp)
+}
+
+// BasisPoints returns the amount scaled by basis points (1/100th of a
+// percent), rounded half away from zero to the currency's minor unit
+func (m Money) BasisPoints(bp int64) (Money, error) {
+	product, err := mulInt64(m.Amount, bp)
+	if err != nil {
+		return Money{}, err
+	}
+
+	amount, remainder := product/10000, product%10000
+	if remainder >= 5000 {
+		amount++
+	} else if remainder <= -5000 {
+		amount--
+	}
+
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// String formats the amount as a plain decimal followed by the currency,
+// for example (12.34 USD)
+func (m Money) String() string {
+	return m.decimal(".", "") + " " + string(m.Currency)
+}
+
+// decimal formats the amount with the given decimal and grouping separators
+func (m Money) decimal(decimal, group string) string {
+	var sign string
+	amount := uint64(m.Amount)
+	if m.Amount < 0 {
+		sign = "-"
+		amount = uint64(-(m.Amount + 1)) + 1
+	}
+
+	digits := fmt.Sprintf("%0*d", m.Currency.MinorUnits()+1, amount)
+	split := len(digits) - m.Currency.MinorUnits()
+	whole, fraction := digits[:split], digits[split:]
+
+	if group != "" {
+		var grouped strings.Builder
+		for i, d := range whole {
+			if i > 0 && (len(whole)-i)%3 == 0 {
+				grouped.WriteString(group)
+			}
+			grouped.WriteRune(d)
+		}
+		whole = grouped.String()
+	}
+
+	if fraction == "" {
+		return sign + whole
+	}
+
+	return sign + whole + decimal + fraction
+}
+
+// Locale describes how amounts are written in a region.  Like the cldr data
+// it follows, symbols are separated by a no-break space and some locales
+// group digits with a (narrow) no-break space, so amounts never wrap.
+type Locale struct {
+	Decimal     string
+	Group       string
+	SymbolFirst bool
+	SymbolSpace bool
+}
+
+// Locales are the locales known to Format, keyed by bcp 47 tag
+var Locales = map[string]Locale{
+	"en-US": {Decimal: ".", Group: ",", SymbolFirst: true},
+	"en-GB": {Decimal: ".", Group: ",", SymbolFirst: true},
+	"en-CA": {Decimal: ".", Group: ",", SymbolFirst: true},
+	"en-AU": {Decimal: ".", Group: ",", SymbolFirst: true},
+	"ja-JP": {Decimal: ".", Group: ",", SymbolFirst: true},
+	"zh-CN": {Decimal: ".", Group: ",", SymbolFirst: true},
+	"ko-KR": {Decimal: ".", Group: ",", SymbolFirst: true},
+	"de-DE": {Decimal: ",", Group: ".", SymbolSpace: true},
+	"es-ES": {Decimal: ",", Group: ".", SymbolSpace: true},
+	"it-IT": {Decimal: ",", Group: ".", SymbolSpace: true},
+	"pt-BR": {Decimal: ",", Group: ".", SymbolFirst: true, SymbolSpace: true},
+	"fr-FR": {Decimal: ",", Group: "\u202f", SymbolSpace: true},
+	"de-CH": {Decimal: ".", Group: "’", SymbolFirst: true, SymbolSpace: true},
+	"sv-SE": {Decimal: ",", Group: "\u00a0", SymbolSpace: true},
+	"es-MX": {Decimal: ".", Group: ",", SymbolFirst: true},
+	"ar-BH": {Decimal: ".", Group: ",", SymbolFirst: true, SymbolSpace: true},
+}
+
+// Format formats the amount for a locale like (en-US) or (de_DE), for example
+// ($1,234.56) or (1.234,56 €).  Unknown locales are formatted like en-US.
+func (m Money) Format(locale string) string {
+	l, ok := Locales[strings.ReplaceAll(locale, "_", "-")]
+	if !ok {
+		l = Locales["en-US"]
+	}
+
+	amount := m.decimal(l.Decimal, l.Group)
+	sign := ""
+	if strings.HasPrefix(amount, "-") {
+		sign, amount = "-", amount[1:]
+	}
+
+	space := ""
+	if l.SymbolSpace {
+		space = "\u00a0"
+	}
+
+	if l.SymbolFirst {
+		return sign + m.Currency.Symbol() + space + amount
+	}
+	return sign + amount + space + m.Currency.Symbol()
+}
+
+func addInt64(a, b int64) (int64, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, ErrMoneyOverflow
+	}
+	return a + b, nil
+}
+
+func mulInt64(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+
+	result := a * b
+	if result/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, ErrMoneyOverflow
+	}
+	return result, nil
+}
@@ -0,0 +1,168 @@
+package utilities_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func TestNewMoneyValueFromString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected utilities.MoneyValue
+	}{
+		{"0.29", 29},
+		{"1.1", 110},
+		{"12", 1200},
+		{"19.99", 1999},
+		{"0", 0},
+		{"1000000.01", 100000001},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			result, err := utilities.NewMoneyValueFromString(test.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result != test.expected {
+				t.Errorf("expected %d cents, got %d", test.expected, result)
+			}
+		})
+	}
+
+	for _, input := range []string{"", ".5", "5.", "1.234", "1,000", "1e3", "-1", "+1", " 1", "$1", "1.2.3"} {
+		if _, err := utilities.NewMoneyValueFromString(input); err == nil {
+			t.Errorf("expected %q to be invalid", input)
+		}
+	}
+}
+
+func TestMoneyValueString(t *testing.T) {
+	if s := utilities.NewDollarValue(0.29).String(); s != "0.29" {
+		t.Errorf("expected 0.29, got %s", s)
+	}
+
+	for _, value := range []float64{1e300, math.Inf(1)} {
+		if cents := utilities.NewDollarValue(value).CentsValue(); cents != math.MaxUint {
+			t.Errorf("%g: expected the largest value, got %d", value, cents)
+		}
+	}
+	if cents := utilities.NewDollarValue(math.NaN()).CentsValue(); cents != 0 {
+		t.Errorf("expected NaN to be 0, got %d", cents)
+	}
+
+	if s := utilities.NewCentValue(100005).String(); s != "1000.05" {
+		t.Errorf("expected 1000.05, got %s", s)
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input    string
+		currency utilities.Currency
+		expected int64
+		valid    bool
+	}{
+		{"12.34", utilities.USD, 1234, true},
+		{"-12.3", utilities.EUR, -1230, true},
+		{"500", utilities.JPY, 500, true},
+		{"500.5", utilities.JPY, 0, false},
+		{"1.234", utilities.BHD, 1234, true},
+		{"1.2345", utilities.BHD, 0, false},
+		{"92233720368547758.07", utilities.USD, math.MaxInt64, true},
+		{"92233720368547758.08", utilities.USD, 0, false},
+		{"1.00", utilities.Currency("XXX"), 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input+" "+test.currency.String(), func(t *testing.T) {
+			result, err := utilities.ParseMoney(test.input, test.currency)
+			if (err == nil) != test.valid {
+				t.Fatalf("expected valid to be %t, got %v", test.valid, err)
+			}
+
+			if test.valid && result.Amount != test.expected {
+				t.Errorf("expected %d, got %d", test.expected, result.Amount)
+			}
+		})
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	price := utilities.Money{Amount: 1999, Currency: utilities.USD}
+
+	total, err := price.Mul(3)
+	if err != nil || total.Amount != 5997 {
+		t.Errorf("expected 5997, got %d (%v)", total.Amount, err)
+	}
+
+	total, err = total.Add(utilities.Money{Amount: 3, Currency: utilities.USD})
+	if err != nil || total.Amount != 6000 {
+		t.Errorf("expected 6000, got %d (%v)", total.Amount, err)
+	}
+
+	if _, err := price.Add(utilities.Money{Amount: 1, Currency: utilities.EUR}); !errors.Is(err, utilities.ErrCurrencyMismatch) {
+		t.Errorf("expected currency mismatch, got %v", err)
+	}
+
+	if _, err := (utilities.Money{Amount: math.MaxInt64, Currency: utilities.USD}).Add(utilities.Money{Amount: 1, Currency: utilities.USD}); !errors.Is(err, utilities.ErrMoneyOverflow) {
+		t.Errorf("expected overflow, got %v", err)
+	}
+
+	if _, err := price.Mul(math.MaxInt64); !errors.Is(err, utilities.ErrMoneyOverflow) {
+		t.Errorf("expected overflow, got %v", err)
+	}
+
+	tests := []struct {
+		amount   int64
+		percent  int64
+		expected int64
+	}{
+		{1999, 10, 200},
+		{1999, 50, 1000},
+		{1998, 50, 999},
+		{-1999, 50, -1000},
+		{1000, 115, 1150},
+	}
+
+	for _, test := range tests {
+		result, err := utilities.Money{Amount: test.amount, Currency: utilities.USD}.Percent(test.percent)
+		if err != nil || result.Amount != test.expected {
+			t.Errorf("%d%% of %d: expected %d, got %d (%v)", test.percent, test.amount, test.expected, result.Amount, err)
+		}
+	}
+
+	if _, err := (utilities.Money{Amount: 1, Currency: utilities.USD}).Percent(math.MaxInt64 / 10); !errors.Is(err, utilities.ErrMoneyOverflow) {
+		t.Errorf("expected the percentage to overflow, got %v", err)
+	}
+}
+
+func TestMoneyFormat(t *testing.T) {
+	tests := []struct {
+		money    utilities.Money
+		locale   string
+		expected string
+	}{
+		{utilities.Money{Amount: 123456, Currency: utilities.USD}, "en-US", "$1,234.56"},
+		{utilities.Money{Amount: -5, Currency: utilities.USD}, "en-US", "-$0.05"},
+		{utilities.Money{Amount: 123456, Currency: utilities.EUR}, "de-DE", "1.234,56\u00a0€"},
+		{utilities.Money{Amount: 123456, Currency: utilities.EUR}, "fr_FR", "1\u202f234,56\u00a0€"},
+		{utilities.Money{Amount: 1234567, Currency: utilities.JPY}, "ja-JP", "¥1,234,567"},
+		{utilities.Money{Amount: 1234, Currency: utilities.BHD}, "ar-BH", "BHD\u00a01.234"},
+		{utilities.Money{Amount: 100, Currency: utilities.GBP}, "xx-XX", "£1.00"},
+	}
+
+	for _, test := range tests {
+		if result := test.money.Format(test.locale); result != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, result)
+		}
+	}
+
+	if s := (utilities.Money{Amount: math.MinInt64, Currency: utilities.USD}).String(); s != "-92233720368547758.08 USD" {
+		t.Errorf("unexpected string %s", s)
+	}
+}
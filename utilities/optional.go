@@ -0,0 +1,90 @@
+package utilities
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+type optionalState uint8
+
+const (
+	optionalUnset optionalState = iota
+	optionalNull
+	optionalValue
+)
+
+// Optional is a field of a partial update, which is either unset (left
+// unchanged), null (cleared) or set to a value.  The zero value is unset.
+type Optional[T any] struct {
+	value T
+	state optionalState
+}
+
+// NewOptional returns an optional set to the value
+func NewOptional[T any](value T) Optional[T] {
+	return Optional[T]{value: value, state: optionalValue}
+}
+
+// NullOptional returns an optional that clears the field
+func NullOptional[T any]() Optional[T] {
+	return Optional[T]{state: optionalNull}
+}
+
+// NewOptionalPointer returns an optional set to the value pointed to, or
+// unset when the pointer is nil
+func NewOptionalPointer[T any](value *T) Optional[T] {
+	if value == nil {
+		return Optional[T]{}
+	}
+	return NewOptional(*value)
+}
+
+// IsSet reports whether the optional changes the field, either to a value or to null
+func (o Optional[T]) IsSet() bool {
+	return o.state != optionalUnset
+}
+
+// IsNull reports whether the optional clears the field
+func (o Optional[T]) IsNull() bool {
+	return o.state == optionalNull
+}
+
+// Get returns the value, and false when the optional is unset or null
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.state == optionalValue
+}
+
+// Pointer returns a pointer to the value, or nil when the optional is unset or null
+func (o Optional[T]) Pointer() *T {
+	if o.state != optionalValue {
+		return nil
+	}
+	value := o.value
+	return &value
+}
+
+// MarshalJSON encodes the value, or null when the optional is null.  Unset
+// optionals also encode as null, so containers should leave them out.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if o.state != optionalValue {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes null as a null optional and anything else as a value.
+// Fields missing from the json are left unset.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = NullOptional[T]()
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*o = NewOptional(value)
+	return nil
+}
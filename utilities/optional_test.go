@@ -0,0 +1,62 @@
+package utilities_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func TestOptional(t *testing.T) {
+	var unset utilities.Optional[string]
+	if unset.IsSet() || unset.IsNull() || unset.Pointer() != nil {
+		t.Errorf("expected the zero value to be unset")
+	}
+
+	null := utilities.NullOptional[string]()
+	if !null.IsSet() || !null.IsNull() {
+		t.Errorf("expected a null optional to be set and null")
+	}
+
+	value := utilities.NewOptional("blue")
+	if v, ok := value.Get(); !ok || v != "blue" || value.IsNull() {
+		t.Errorf("expected a set optional to hold its value")
+	}
+
+	if utilities.NewOptionalPointer[string](nil).IsSet() {
+		t.Errorf("expected a nil pointer to be unset")
+	}
+}
+
+func TestOptionalJSON(t *testing.T) {
+	var input struct {
+		Color utilities.Optional[string] `json:"color"`
+		Url   utilities.Optional[string] `json:"url"`
+		Logo  utilities.Optional[string] `json:"logo"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"color":"blue","url":null}`), &input); err != nil {
+		t.Fatal(err)
+	}
+
+	if color, ok := input.Color.Get(); !ok || color != "blue" {
+		t.Errorf("expected color to be set to blue")
+	}
+
+	if !input.Url.IsNull() {
+		t.Errorf("expected url to be null")
+	}
+
+	if input.Logo.IsSet() {
+		t.Errorf("expected a missing logo to be unset")
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `{"color":"blue","url":null,"logo":null}` {
+		t.Errorf("unexpected json %s", data)
+	}
+}
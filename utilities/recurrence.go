@@ -1,41 +1,427 @@
 package utilities
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
-type Recurrence string
+// RecurrenceUnit is the calendar unit a recurrence repeats in
+type RecurrenceUnit string
+
+const (
+	RecurrenceDay   RecurrenceUnit = "day"
+	RecurrenceWeek  RecurrenceUnit = "week"
+	RecurrenceMonth RecurrenceUnit = "month"
+	RecurrenceYear  RecurrenceUnit = "year"
+)
+
+// Period is a number of calendar units, for example 14 days
+type Period struct {
+	Unit  RecurrenceUnit
+	Count uint
+}
+
+// IsZero reports whether the period is unset
+func (p Period) IsZero() bool {
+	return p.Count == 0
+}
+
+// ISO8601 formats the period as an iso 8601 duration, for example (P14D)
+func (p Period) ISO8601() string {
+	return fmt.Sprintf("P%d%s", p.Count, isoDesignators[p.Unit])
+}
+
+func (p Period) String() string {
+	unit := string(p.Unit)
+	if p.Count != 1 {
+		unit += "s"
+	}
+
+	return fmt.Sprintf("%d %s", p.Count, unit)
+}
+
+// Recurrence is how often a script is billed: every Interval Units, or only
+// once when OneTime is set, with an optional free Trial before the first charge
+type Recurrence struct {
+	Unit     RecurrenceUnit
+	Interval uint
+	OneTime  bool
+	Trial    Period
+}
+
+var (
+	Daily   = Recurrence{Unit: RecurrenceDay, Interval: 1}
+	Weekly  = Recurrence{Unit: RecurrenceWeek, Interval: 1}
+	Monthly = Recurrence{Unit: RecurrenceMonth, Interval: 1}
+	Yearly  = Recurrence{Unit: RecurrenceYear, Interval: 1}
+	Once    = Recurrence{OneTime: true}
+)
+
+var (
+	// the legacy scalar values, which are still used on the wire for intervals of 1
+	legacyKeywords = map[RecurrenceUnit]string{
+		RecurrenceDay:   "daily",
+		RecurrenceWeek:  "weekly",
+		RecurrenceMonth: "monthly",
+		RecurrenceYear:  "yearly",
+	}
+	isoDesignators = map[RecurrenceUnit]string{
+		RecurrenceDay:   "D",
+		RecurrenceWeek:  "W",
+		RecurrenceMonth: "M",
+		RecurrenceYear:  "Y",
+	}
+
+	isoPeriodRegexp   = regexp.MustCompile(`^p(\d+)([dwmy])$`)
+	everyRegexp       = regexp.MustCompile(`^every\s+(?:(\d+)\s+)?(day|week|month|year)s?$`)
+	plainPeriodRegexp = regexp.MustCompile(`^(\d+)[\s-]*(day|week|month|year)s?$`)
+)
 
 func errInvalidRecurrence(val string) error {
-	return fmt.Errorf("invalid recurrence.  expecting one of (daily, weekly, monthly, yearly). received: %s", val)
+	return fmt.Errorf("invalid recurrence.  expecting one of (daily, weekly, monthly, yearly, quarterly, once), (every 3 months), or an iso 8601 duration like (P3M), optionally followed by a trial like (;trial=P14D). received: %s", val)
+}
+
+// Every returns a recurrence of every interval units
+func Every(interval uint, unit RecurrenceUnit) Recurrence {
+	return Recurrence{Unit: unit, Interval: interval}
 }
 
+// WithTrial returns a copy of the recurrence with a free trial period
+func (r Recurrence) WithTrial(trial Period) Recurrence {
+	r.Trial = trial
+	return r
+}
+
+// NewRecurrence parses a recurrence like (monthly), (every 3 months), (P3M)
+// or (once), optionally followed by a trial like (;trial=P14D) or (;trial=14 days)
 func NewRecurrence(val string) (*Recurrence, error) {
-	val = strings.ToLower(val)
+	normalized := strings.ToLower(strings.TrimSpace(val))
 
 	var res Recurrence
+	main, trial, hasTrial := strings.Cut(normalized, ";")
+	main = strings.TrimSpace(main)
 
-	switch val {
-	case "daily":
-		res = Recurrence(val)
-	case "weekly":
-		res = Recurrence(val)
-	case "monthly":
-		res = Recurrence(val)
-	case "yearly":
-		res = Recurrence(val)
+	switch main {
+	case "once", "one-time", "one_time", "onetime":
+		res = Once
+	case "quarterly":
+		res = Every(3, RecurrenceMonth)
+	case "annually":
+		res = Yearly
 	default:
-		return nil, errInvalidRecurrence(val)
+		found := false
+		for unit, keyword := range legacyKeywords {
+			if main == keyword {
+				res = Every(1, unit)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			period, ok := parsePeriod(main)
+			if !ok {
+				return nil, errInvalidRecurrence(val)
+			}
+			res = Every(period.Count, period.Unit)
+		}
+	}
+
+	if hasTrial {
+		key, value, ok := strings.Cut(strings.TrimSpace(trial), "=")
+		if !ok || strings.TrimSpace(key) != "trial" {
+			return nil, errInvalidRecurrence(val)
+		}
+
+		period, ok := parsePeriod(strings.TrimSpace(value))
+		if !ok {
+			return nil, errInvalidRecurrence(val)
+		}
+		res.Trial = period
+	}
+
+	if err := res.Validate(); err != nil {
+		return nil, err
 	}
 
 	return &res, nil
 }
 
+// parsePeriod parses (P14D), (every 2 weeks) or (14 days)
+func parsePeriod(val string) (Period, bool) {
+	units := map[string]RecurrenceUnit{
+		"d": RecurrenceDay, "w": RecurrenceWeek, "m": RecurrenceMonth, "y": RecurrenceYear,
+		"day": RecurrenceDay, "week": RecurrenceWeek, "month": RecurrenceMonth, "year": RecurrenceYear,
+	}
+
+	var count, unit string
+	if m := isoPeriodRegexp.FindStringSubmatch(val); m != nil {
+		count, unit = m[1], m[2]
+	} else if m := everyRegexp.FindStringSubmatch(val); m != nil {
+		count, unit = m[1], m[2]
+		if count == "" {
+			count = "1"
+		}
+	} else if m := plainPeriodRegexp.FindStringSubmatch(val); m != nil {
+		count, unit = m[1], m[2]
+	} else {
+		return Period{}, false
+	}
+
+	n, err := strconv.ParseUint(count, 10, 32)
+	if err != nil || n == 0 {
+		return Period{}, false
+	}
+
+	return Period{Unit: units[unit], Count: uint(n)}, true
+}
+
+// Validate checks the recurrence is either one-time or repeats in a known unit
+func (r Recurrence) Validate() error {
+	if r.OneTime {
+		if r.Unit != "" || r.Interval != 0 {
+			return fmt.Errorf("invalid recurrence. a one-time recurrence cannot repeat every %d %s", r.Interval, r.Unit)
+		}
+	} else {
+		if _, ok := legacyKeywords[r.Unit]; !ok {
+			return fmt.Errorf("invalid recurrence unit %q. expecting one of (day, week, month, year)", r.Unit)
+		}
+		if r.Interval == 0 {
+			return fmt.Errorf("invalid recurrence. the interval must be at least 1")
+		}
+	}
+
+	if !r.Trial.IsZero() {
+		if _, ok := legacyKeywords[r.Trial.Unit]; !ok {
+			return fmt.Errorf("invalid trial unit %q. expecting one of (day, week, month, year)", r.Trial.Unit)
+		}
+	}
+
+	return nil
+}
+
+// IsZero reports whether the recurrence is unset
+func (r Recurrence) IsZero() bool {
+	return r == Recurrence{}
+}
+
+// Period returns the length of one billing period
+func (r Recurrence) Period() Period {
+	return Period{Unit: r.Unit, Count: r.Interval}
+}
+
+// String formats the recurrence in the form sent to the api, which
+// NewRecurrence parses back.  Intervals of 1 use the legacy keywords (daily,
+// weekly, monthly, yearly), others use iso 8601 durations like (P3M).
 func (r Recurrence) String() string {
-	return string(r)
+	if r.IsZero() {
+		return ""
+	}
+
+	var s string
+	switch {
+	case r.OneTime:
+		s = "once"
+	case r.Interval == 1:
+		s = legacyKeywords[r.Unit]
+	default:
+		s = r.Period().ISO8601()
+	}
+
+	if !r.Trial.IsZero() {
+		s += ";trial=" + r.Trial.ISO8601()
+	}
+
+	return s
+}
+
+// Describe formats the recurrence for people, for example (every 3 months with a 14 day trial)
+func (r Recurrence) Describe() string {
+	var s string
+	switch {
+	case r.IsZero():
+		return ""
+	case r.OneTime:
+		s = "one-time"
+	case r.Interval == 1:
+		s = legacyKeywords[r.Unit]
+	default:
+		s = "every " + r.Period().String()
+	}
+
+	if !r.Trial.IsZero() {
+		s += fmt.Sprintf(" with a %d %s trial", r.Trial.Count, r.Trial.Unit)
+	}
+
+	return s
+}
+
+// occurrence returns the start of the nth billing period after the anchor.
+// Months and years are added to the anchor itself rather than to the previous
+// occurrence, so a Jan 31 anchor is clamped to Feb 28 and is back to Mar 31 a
+// month later.  Dates are computed on the anchor's wall clock in its location.
+func (r Recurrence) occurrence(anchor time.Time, n int) time.Time {
+	count := n * int(r.Interval)
+
+	switch r.Unit {
+	case RecurrenceDay:
+		return anchor.AddDate(0, 0, count)
+	case RecurrenceWeek:
+		return anchor.AddDate(0, 0, 7*count)
+	case RecurrenceYear:
+		count *= 12
+	}
+
+	year, month, day := anchor.Date()
+	hour, min, sec := anchor.Clock()
+
+	// normalize the month first, then clamp the day to its length
+	first := time.Date(year, month+time.Month(count), 1, 0, 0, 0, 0, anchor.Location())
+	if last := daysIn(first.Year(), first.Month()); day > last {
+		day = last
+	}
+
+	return time.Date(first.Year(), first.Month(), day, hour, min, sec, anchor.Nanosecond(), anchor.Location())
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// periodIndex returns the index of the billing period containing t, which is
+// negative when t is before the anchor
+func (r Recurrence) periodIndex(anchor, t time.Time) int {
+	t = t.In(anchor.Location())
+
+	// estimate from the calendar, then step to the exact period
+	var n int
+	switch r.Unit {
+	case RecurrenceDay, RecurrenceWeek:
+		days := int(t.Sub(anchor).Hours() / 24)
+		if r.Unit == RecurrenceWeek {
+			days /= 7
+		}
+		n = days / int(r.Interval)
+	default:
+		months := (t.Year()-anchor.Year())*12 + int(t.Month()-anchor.Month())
+		if r.Unit == RecurrenceYear {
+			months /= 12
+		}
+		n = months / int(r.Interval)
+	}
+
+	for r.occurrence(anchor, n).After(t) {
+		n--
+	}
+	for !r.occurrence(anchor, n+1).After(t) {
+		n++
+	}
+
+	return n
+}
+
+// NextOccurrence returns the first billing date strictly after the given
+// time, for a subscription first billed at anchor.  It returns false when
+// there is none, which is when a one-time recurrence was already billed.
+func (r Recurrence) NextOccurrence(anchor, after time.Time) (time.Time, bool) {
+	if anchor.After(after) {
+		return anchor, true
+	}
+
+	if r.OneTime || r.Validate() != nil {
+		return time.Time{}, false
+	}
+
+	return r.occurrence(anchor, r.periodIndex(anchor, after)+1), true
+}
+
+// PeriodContaining returns the start and end of the billing period that
+// contains t, for a subscription first billed at anchor.  The start is
+// inclusive and the end exclusive.  It returns false when t is before the
+// anchor, or the recurrence is one-time and has no periods.
+func (r Recurrence) PeriodContaining(anchor, t time.Time) (start time.Time, end time.Time, ok bool) {
+	if r.OneTime || r.Validate() != nil || t.Before(anchor) {
+		return time.Time{}, time.Time{}, false
+	}
+
+	n := r.periodIndex(anchor, t)
+	return r.occurrence(anchor, n), r.occurrence(anchor, n+1), true
+}
+
+// PeriodsBetween returns the number of whole billing periods between from and
+// to, counting periods from from.  It is negative when to is before from, and
+// always 0 for a one-time recurrence.
+func (r Recurrence) PeriodsBetween(from, to time.Time) int {
+	if r.OneTime || r.Validate() != nil {
+		return 0
+	}
+
+	if to.Before(from) {
+		return -r.PeriodsBetween(to, from)
+	}
+
+	return r.periodIndex(from, to)
 }
 
 func (r Recurrence) GetGraphQLType() string {
 	return "Recurrence"
 }
+
+func (r Recurrence) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+func (r *Recurrence) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	return r.UnmarshalText([]byte(s))
+}
+
+func (r Recurrence) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText parses and validates a recurrence.  An empty string leaves it unset.
+func (r *Recurrence) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*r = Recurrence{}
+		return nil
+	}
+
+	parsed, err := NewRecurrence(string(text))
+	if err != nil {
+		return err
+	}
+
+	*r = *parsed
+	return nil
+}
+
+// Value stores the recurrence in its wire format, for example (monthly) or (P3M;trial=P14D)
+func (r Recurrence) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// Scan reads and validates a recurrence.  Use sql.Null[Recurrence] for nullable columns.
+func (r *Recurrence) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return r.UnmarshalText([]byte(v))
+	case []byte:
+		return r.UnmarshalText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into a recurrence", src)
+	}
+}
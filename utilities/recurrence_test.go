@@ -0,0 +1,200 @@
+package utilities_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/myscribae/myscribae-sdk-go/utilities"
+)
+
+func TestNewRecurrence(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected utilities.Recurrence
+		wire     string
+	}{
+		{"monthly", utilities.Monthly, "monthly"},
+		{"Weekly", utilities.Weekly, "weekly"},
+		{"every 3 months", utilities.Every(3, utilities.RecurrenceMonth), "P3M"},
+		{"every week", utilities.Weekly, "weekly"},
+		{"quarterly", utilities.Every(3, utilities.RecurrenceMonth), "P3M"},
+		{"P2W", utilities.Every(2, utilities.RecurrenceWeek), "P2W"},
+		{"P1Y", utilities.Yearly, "yearly"},
+		{"once", utilities.Once, "once"},
+		{"one-time", utilities.Once, "once"},
+		{
+			"monthly;trial=P14D",
+			utilities.Monthly.WithTrial(utilities.Period{Unit: utilities.RecurrenceDay, Count: 14}),
+			"monthly;trial=P14D",
+		},
+		{
+			"every 6 months; trial=1 month",
+			utilities.Every(6, utilities.RecurrenceMonth).WithTrial(utilities.Period{Unit: utilities.RecurrenceMonth, Count: 1}),
+			"P6M;trial=P1M",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			result, err := utilities.NewRecurrence(test.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if *result != test.expected {
+				t.Errorf("expected %+v, got %+v", test.expected, *result)
+			}
+
+			if result.String() != test.wire {
+				t.Errorf("expected %s on the wire, got %s", test.wire, result.String())
+			}
+
+			// the wire format parses back to the same recurrence
+			roundTrip, err := utilities.NewRecurrence(result.String())
+			if err != nil || *roundTrip != *result {
+				t.Errorf("wire format %s did not round trip", result.String())
+			}
+		})
+	}
+}
+
+func TestNewRecurrenceInvalid(t *testing.T) {
+	for _, input := range []string{"", "fortnightly", "every 0 months", "P3H", "P1M2D", "monthly;trial", "monthly;bonus=P1D"} {
+		if _, err := utilities.NewRecurrence(input); err == nil {
+			t.Errorf("expected %q to be invalid", input)
+		}
+	}
+}
+
+func TestRecurrenceJSON(t *testing.T) {
+	var input struct {
+		Recurrence utilities.Recurrence `json:"recurrence"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"recurrence": "every 2 weeks"}`), &input); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `{"recurrence":"P2W"}` {
+		t.Errorf("unexpected json %s", data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"recurrence": "sometimes"}`), &input); err == nil {
+		t.Errorf("expected an invalid recurrence error")
+	}
+}
+
+func date(year int, month time.Month, day int, loc *time.Location) time.Time {
+	return time.Date(year, month, day, 9, 30, 0, 0, loc)
+}
+
+func TestRecurrenceNextOccurrence(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database is unavailable")
+	}
+
+	tests := []struct {
+		name       string
+		recurrence utilities.Recurrence
+		anchor     time.Time
+		after      time.Time
+		expected   time.Time
+		ok         bool
+	}{
+		{"before the anchor", utilities.Monthly, date(2024, time.March, 15, time.UTC), date(2024, time.January, 1, time.UTC), date(2024, time.March, 15, time.UTC), true},
+		{"on the anchor", utilities.Monthly, date(2024, time.March, 15, time.UTC), date(2024, time.March, 15, time.UTC), date(2024, time.April, 15, time.UTC), true},
+		{"month end clamps", utilities.Monthly, date(2023, time.January, 31, time.UTC), date(2023, time.February, 1, time.UTC), date(2023, time.February, 28, time.UTC), true},
+		{"month end clamps in a leap year", utilities.Monthly, date(2024, time.January, 31, time.UTC), date(2024, time.February, 1, time.UTC), date(2024, time.February, 29, time.UTC), true},
+		{"month end recovers after clamping", utilities.Monthly, date(2023, time.January, 31, time.UTC), date(2023, time.February, 28, time.UTC), date(2023, time.March, 31, time.UTC), true},
+		{"leap day yearly", utilities.Yearly, date(2024, time.February, 29, time.UTC), date(2024, time.March, 1, time.UTC), date(2025, time.February, 28, time.UTC), true},
+		{"leap day returns in leap years", utilities.Yearly, date(2024, time.February, 29, time.UTC), date(2027, time.March, 1, time.UTC), date(2028, time.February, 29, time.UTC), true},
+		{"quarterly", utilities.Every(3, utilities.RecurrenceMonth), date(2024, time.November, 30, time.UTC), date(2024, time.December, 1, time.UTC), date(2025, time.February, 28, time.UTC), true},
+		{"every 2 weeks", utilities.Every(2, utilities.RecurrenceWeek), date(2024, time.January, 1, time.UTC), date(2024, time.January, 20, time.UTC), date(2024, time.January, 29, time.UTC), true},
+		{"daily", utilities.Daily, date(2024, time.January, 1, time.UTC), date(2024, time.February, 29, time.UTC).Add(time.Hour), date(2024, time.March, 1, time.UTC), true},
+		{"keeps the wall clock across dst", utilities.Daily, date(2024, time.March, 9, newYork), date(2024, time.March, 9, newYork).Add(time.Hour), date(2024, time.March, 10, newYork), true},
+		{"after in another time zone", utilities.Monthly, date(2024, time.January, 31, newYork), date(2024, time.March, 1, time.UTC), date(2024, time.March, 31, newYork), true},
+		{"one-time before billing", utilities.Once, date(2024, time.March, 15, time.UTC), date(2024, time.January, 1, time.UTC), date(2024, time.March, 15, time.UTC), true},
+		{"one-time after billing", utilities.Once, date(2024, time.March, 15, time.UTC), date(2024, time.April, 1, time.UTC), time.Time{}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, ok := test.recurrence.NextOccurrence(test.anchor, test.after)
+			if ok != test.ok {
+				t.Fatalf("expected ok to be %t", test.ok)
+			}
+
+			if !result.Equal(test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestRecurrencePeriodContaining(t *testing.T) {
+	tests := []struct {
+		name       string
+		recurrence utilities.Recurrence
+		anchor     time.Time
+		t          time.Time
+		start      time.Time
+		end        time.Time
+		ok         bool
+	}{
+		{"first period", utilities.Monthly, date(2024, time.January, 31, time.UTC), date(2024, time.February, 10, time.UTC), date(2024, time.January, 31, time.UTC), date(2024, time.February, 29, time.UTC), true},
+		{"period start is inclusive", utilities.Monthly, date(2024, time.January, 31, time.UTC), date(2024, time.February, 29, time.UTC), date(2024, time.February, 29, time.UTC), date(2024, time.March, 31, time.UTC), true},
+		{"period end is exclusive", utilities.Monthly, date(2024, time.January, 31, time.UTC), date(2024, time.March, 31, time.UTC).Add(-time.Nanosecond), date(2024, time.February, 29, time.UTC), date(2024, time.March, 31, time.UTC), true},
+		{"weekly", utilities.Weekly, date(2024, time.January, 1, time.UTC), date(2024, time.January, 17, time.UTC), date(2024, time.January, 15, time.UTC), date(2024, time.January, 22, time.UTC), true},
+		{"yearly", utilities.Yearly, date(2020, time.February, 29, time.UTC), date(2023, time.June, 1, time.UTC), date(2023, time.February, 28, time.UTC), date(2024, time.February, 29, time.UTC), true},
+		{"before the anchor", utilities.Monthly, date(2024, time.January, 31, time.UTC), date(2024, time.January, 1, time.UTC), time.Time{}, time.Time{}, false},
+		{"one-time", utilities.Once, date(2024, time.January, 31, time.UTC), date(2024, time.February, 1, time.UTC), time.Time{}, time.Time{}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start, end, ok := test.recurrence.PeriodContaining(test.anchor, test.t)
+			if ok != test.ok {
+				t.Fatalf("expected ok to be %t", test.ok)
+			}
+
+			if !start.Equal(test.start) || !end.Equal(test.end) {
+				t.Errorf("expected %s to %s, got %s to %s", test.start, test.end, start, end)
+			}
+		})
+	}
+}
+
+func TestRecurrencePeriodsBetween(t *testing.T) {
+	tests := []struct {
+		name       string
+		recurrence utilities.Recurrence
+		from       time.Time
+		to         time.Time
+		expected   int
+	}{
+		{"same time", utilities.Monthly, date(2024, time.January, 31, time.UTC), date(2024, time.January, 31, time.UTC), 0},
+		{"partial period", utilities.Monthly, date(2024, time.January, 31, time.UTC), date(2024, time.February, 28, time.UTC), 0},
+		{"clamped month end", utilities.Monthly, date(2024, time.January, 31, time.UTC), date(2024, time.February, 29, time.UTC), 1},
+		{"a year of months", utilities.Monthly, date(2023, time.January, 31, time.UTC), date(2024, time.January, 31, time.UTC), 12},
+		{"quarters", utilities.Every(3, utilities.RecurrenceMonth), date(2024, time.January, 1, time.UTC), date(2024, time.December, 31, time.UTC), 3},
+		{"days over a leap day", utilities.Daily, date(2024, time.February, 28, time.UTC), date(2024, time.March, 1, time.UTC), 2},
+		{"weeks", utilities.Weekly, date(2024, time.January, 1, time.UTC), date(2024, time.March, 1, time.UTC), 8},
+		{"reversed", utilities.Monthly, date(2024, time.April, 15, time.UTC), date(2024, time.January, 15, time.UTC), -3},
+		{"one-time", utilities.Once, date(2024, time.January, 1, time.UTC), date(2025, time.January, 1, time.UTC), 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := test.recurrence.PeriodsBetween(test.from, test.to); result != test.expected {
+				t.Errorf("expected %d periods, got %d", test.expected, result)
+			}
+		})
+	}
+}
@@ -1,6 +1,12 @@
 package utilities
 
-import "fmt"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
 
 type UInt uint
 
@@ -28,3 +34,62 @@ func NewUIntPointer(val *uint) *UInt {
 	result := NewUInt(*val)
 	return &result
 }
+
+func (u UInt) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatUint(uint64(u), 10)), nil
+}
+
+// UnmarshalJSON decodes a non-negative whole number
+func (u *UInt) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	// json.Number also accepts quoted numbers, which are ambiguous
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil || data[0] == '"' {
+		return fmt.Errorf("invalid uint.  expecting a number. received: %s", data)
+	}
+
+	return u.UnmarshalText([]byte(n.String()))
+}
+
+func (u UInt) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText decodes a non-negative whole number
+func (u *UInt) UnmarshalText(text []byte) error {
+	val, err := strconv.ParseUint(string(text), 10, strconv.IntSize)
+	if err != nil {
+		return fmt.Errorf("invalid uint.  expecting a non-negative whole number. received: %s", text)
+	}
+
+	*u = UInt(val)
+	return nil
+}
+
+func (u UInt) Value() (driver.Value, error) {
+	if uint64(u) > math.MaxInt64 {
+		return nil, fmt.Errorf("uint %d overflows an int64", u)
+	}
+	return int64(u), nil
+}
+
+// Scan reads a non-negative integer.  Use sql.Null[UInt] for nullable columns.
+func (u *UInt) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("invalid uint.  expecting a non-negative number. received: %d", v)
+		}
+		*u = UInt(v)
+		return nil
+	case []byte:
+		return u.UnmarshalText(v)
+	case string:
+		return u.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("cannot scan %T into a uint", src)
+	}
+}